@@ -0,0 +1,646 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gidyon/jumia-exercise/internal/exporter"
+	"github.com/gidyon/jumia-exercise/internal/importer"
+	phonebook_v1 "github.com/gidyon/jumia-exercise/pkg/api/phonebook/v1"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// registerPhonesAPI adds the JSON REST API for phone records, each route maps to a PhoneBookService method
+func registerPhonesAPI(router gin.IRouter, appV1 phonebook_v1.PhoneBookService) {
+	phones := router.Group("/api/v1/phones")
+
+	phones.POST("", func(c *gin.Context) {
+		req := &phonebook_v1.PhoneRecord{}
+		if err := c.ShouldBindJSON(req); err != nil {
+			abortWithError(c, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+
+		res, err := appV1.CreatePhoneRecord(c.Request.Context(), req)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, res)
+	})
+
+	phones.POST("/validate", func(c *gin.Context) {
+		req := &phonebook_v1.ValidatePhoneNumberRequest{}
+		if err := c.ShouldBindJSON(req); err != nil {
+			abortWithError(c, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+
+		res, err := appV1.ValidatePhoneNumber(c.Request.Context(), req)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, res)
+	})
+
+	phones.POST("/batch_create", func(c *gin.Context) {
+		req := &phonebook_v1.BatchCreatePhoneRecordsRequest{}
+		if err := c.ShouldBindJSON(req); err != nil {
+			abortWithError(c, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+
+		res, err := appV1.BatchCreatePhoneRecords(c.Request.Context(), req)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, res)
+	})
+
+	phones.POST("/batch_delete", func(c *gin.Context) {
+		req := &phonebook_v1.BatchDeletePhoneRecordsRequest{}
+		if err := c.ShouldBindJSON(req); err != nil {
+			abortWithError(c, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+
+		res, err := appV1.BatchDeletePhoneRecords(c.Request.Context(), req)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, res)
+	})
+
+	phones.POST("/import", func(c *gin.Context) {
+		opt, err := importOptionsFromQuery(c)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		rows := &importRows{Rows: []*importer.RowResult{}}
+		report, err := importUpload(c, appV1, opt, rows)
+		if err != nil {
+			code := importErrorCode(err)
+			c.AbortWithStatusJSON(httpStatus(code), gin.H{
+				"code":           code.String(),
+				"error":          err.Error(),
+				"report":         report,
+				"rows":           rows.Rows,
+				"rows_truncated": rows.Truncated,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"report":         report,
+			"rows":           rows.Rows,
+			"rows_truncated": rows.Truncated,
+		})
+	})
+
+	phones.GET("/:id", func(c *gin.Context) {
+		res, err := appV1.GetPhoneRecord(c.Request.Context(), &phonebook_v1.GetPhoneRecordRequest{
+			RecordId: c.Param("id"),
+		})
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, res)
+	})
+
+	phones.PATCH("/:id", func(c *gin.Context) {
+		req := &phonebook_v1.UpdatePhoneRecordRequest{}
+		if err := c.ShouldBindJSON(req); err != nil {
+			abortWithError(c, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+		if req.PhoneRecord == nil {
+			req.PhoneRecord = &phonebook_v1.PhoneRecord{}
+		}
+		req.PhoneRecord.Id = c.Param("id")
+
+		res, err := appV1.UpdatePhoneRecord(c.Request.Context(), req)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, res)
+	})
+
+	phones.GET("", func(c *gin.Context) {
+		req, err := listRequestFromQuery(c)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		res, err := appV1.ListPhoneRecords(c.Request.Context(), req)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, res)
+	})
+
+	phones.DELETE("/:id", func(c *gin.Context) {
+		err := appV1.DeletePhoneRecord(c.Request.Context(), &phonebook_v1.DeletePhoneRecordRequest{
+			RecordId:  c.Param("id"),
+			DeletedBy: c.Query("deleted_by"),
+		})
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	})
+
+	// Export lives outside the phones group, gin cannot mix /phones/export with /phones/:id
+	router.GET("/api/v1/export/phones", func(c *gin.Context) {
+		req, err := listRequestFromQuery(c)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		exportPhones(c, appV1, &phonebook_v1.ExportPhoneRecordsRequest{
+			Filters: req.Filters,
+			OrderBy: req.OrderBy,
+		})
+	})
+
+	// Trash lives in its own group, gin cannot mix /phones/validate with /phones/:id/restore
+	deleted := router.Group("/api/v1/deleted_phones")
+
+	deleted.GET("", func(c *gin.Context) {
+		req, err := listRequestFromQuery(c)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		res, err := appV1.ListDeletedPhoneRecords(c.Request.Context(), req)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, res)
+	})
+
+	deleted.POST("/:id/restore", func(c *gin.Context) {
+		res, err := appV1.RestorePhoneRecord(c.Request.Context(), &phonebook_v1.RestorePhoneRecordRequest{
+			RecordId: c.Param("id"),
+		})
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, res)
+	})
+}
+
+// registerCustomersAPI adds the JSON REST API for customers, each route maps to a CustomerService method
+func registerCustomersAPI(router gin.IRouter, customersV1 phonebook_v1.CustomerService) {
+	customers := router.Group("/api/v1/customers")
+
+	customers.POST("", func(c *gin.Context) {
+		req := &phonebook_v1.Customer{}
+		if err := c.ShouldBindJSON(req); err != nil {
+			abortWithError(c, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+
+		res, err := customersV1.CreateCustomer(c.Request.Context(), req)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, res)
+	})
+
+	customers.GET("/:id", func(c *gin.Context) {
+		res, err := customersV1.GetCustomer(c.Request.Context(), &phonebook_v1.GetCustomerRequest{
+			CustomerId: c.Param("id"),
+		})
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, res)
+	})
+
+	customers.PATCH("/:id", func(c *gin.Context) {
+		req := &phonebook_v1.UpdateCustomerRequest{}
+		if err := c.ShouldBindJSON(req); err != nil {
+			abortWithError(c, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+		if req.Customer == nil {
+			req.Customer = &phonebook_v1.Customer{}
+		}
+		req.Customer.Id = c.Param("id")
+
+		res, err := customersV1.UpdateCustomer(c.Request.Context(), req)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, res)
+	})
+
+	customers.GET("", func(c *gin.Context) {
+		pageSize, err := pageSizeFromQuery(c)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		res, err := customersV1.ListCustomers(c.Request.Context(), &phonebook_v1.ListCustomersRequest{
+			PageSize:  pageSize,
+			PageToken: c.Query("page_token"),
+		})
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, res)
+	})
+
+	customers.DELETE("/:id", func(c *gin.Context) {
+		err := customersV1.DeleteCustomer(c.Request.Context(), &phonebook_v1.DeleteCustomerRequest{
+			CustomerId: c.Param("id"),
+		})
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	})
+
+	customers.GET("/:id/phones", func(c *gin.Context) {
+		pageSize, err := pageSizeFromQuery(c)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		res, err := customersV1.ListCustomerPhones(c.Request.Context(), &phonebook_v1.ListCustomerPhonesRequest{
+			CustomerId: c.Param("id"),
+			PageSize:   pageSize,
+			PageToken:  c.Query("page_token"),
+			OrderBy:    c.Query("order_by"),
+		})
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, res)
+	})
+}
+
+// registerCountriesAPI adds the JSON REST API for countries, each route maps to a CountryService method
+func registerCountriesAPI(router gin.IRouter, countriesV1 phonebook_v1.CountryService) {
+	countries := router.Group("/api/v1/countries")
+
+	countries.POST("", func(c *gin.Context) {
+		req := &phonebook_v1.Country{}
+		if err := c.ShouldBindJSON(req); err != nil {
+			abortWithError(c, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+
+		res, err := countriesV1.CreateCountry(c.Request.Context(), req)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, res)
+	})
+
+	countries.GET("/:id", func(c *gin.Context) {
+		res, err := countriesV1.GetCountry(c.Request.Context(), &phonebook_v1.GetCountryRequest{
+			CountryId: c.Param("id"),
+		})
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, res)
+	})
+
+	countries.PATCH("/:id", func(c *gin.Context) {
+		req := &phonebook_v1.UpdateCountryRequest{}
+		if err := c.ShouldBindJSON(req); err != nil {
+			abortWithError(c, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+		if req.Country == nil {
+			req.Country = &phonebook_v1.Country{}
+		}
+		req.Country.Id = c.Param("id")
+
+		res, err := countriesV1.UpdateCountry(c.Request.Context(), req)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, res)
+	})
+
+	countries.GET("", func(c *gin.Context) {
+		req := &phonebook_v1.ListCountriesRequest{}
+		if v := c.Query("enabled_only"); v != "" {
+			var err error
+			req.EnabledOnly, err = strconv.ParseBool(v)
+			if err != nil {
+				abortWithError(c, status.Errorf(codes.InvalidArgument, "incorrect enabled_only: %v", err))
+				return
+			}
+		}
+
+		res, err := countriesV1.ListCountries(c.Request.Context(), req)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, res)
+	})
+
+	countries.POST("/:id/disable", func(c *gin.Context) {
+		res, err := countriesV1.DisableCountry(c.Request.Context(), &phonebook_v1.DisableCountryRequest{
+			CountryId: c.Param("id"),
+		})
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, res)
+	})
+}
+
+// listRequestFromQuery reads list parameters, query keys are the json names of the request fields
+func listRequestFromQuery(c *gin.Context) (*phonebook_v1.ListPhoneRecordsRequest, error) {
+	var (
+		req = &phonebook_v1.ListPhoneRecordsRequest{
+			PageToken: c.Query("page_token"),
+			OrderBy:   c.Query("order_by"),
+			Filters: &phonebook_v1.PhoneRecordsFilters{
+				CountryCode:      c.Query("country_code"),
+				PhoneNumber:      c.Query("phone_number"),
+				NumberType:       c.Query("number_type"),
+				Operator:         c.Query("operator"),
+				PhoneNumberMatch: c.Query("phone_number_match"),
+				CustIds:          queryList(c, "cust_id", "cust_ids"),
+				CreatedAfter:     c.Query("created_after"),
+				CreatedBefore:    c.Query("created_before"),
+			},
+		}
+		err error
+	)
+
+	req.PageSize, err = pageSizeFromQuery(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if v := c.Query("valid_only"); v != "" {
+		req.Filters.ValidOnly, err = strconv.ParseBool(v)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "incorrect valid_only: %v", err)
+		}
+	}
+
+	if v := c.Query("not_valid_only"); v != "" {
+		req.Filters.NotValidOnly, err = strconv.ParseBool(v)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "incorrect not_valid_only: %v", err)
+		}
+	}
+
+	return req, nil
+}
+
+func pageSizeFromQuery(c *gin.Context) (int32, error) {
+	v := c.Query("page_size")
+	if v == "" {
+		return 0, nil
+	}
+	pageSize, err := strconv.ParseInt(v, 10, 32)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "incorrect page_size: %v", err)
+	}
+	return int32(pageSize), nil
+}
+
+// queryList collects the values of repeated and comma separated query keys, e.g. cust_id=1&cust_id=2 or cust_ids=1,2
+func queryList(c *gin.Context, keys ...string) []string {
+	var values []string
+	for _, key := range keys {
+		for _, v := range c.QueryArray(key) {
+			for _, item := range strings.Split(v, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					values = append(values, item)
+				}
+			}
+		}
+	}
+	return values
+}
+
+// importOptionsFromQuery reads import options, column query keys default to importer.DefaultColumns
+func importOptionsFromQuery(c *gin.Context) (*importer.Options, error) {
+	var (
+		opt = &importer.Options{
+			Columns: &importer.Columns{
+				Country: c.DefaultQuery("country_column", importer.DefaultColumns.Country),
+				Phone:   c.DefaultQuery("phone_column", importer.DefaultColumns.Phone),
+				CustId:  c.DefaultQuery("cust_id_column", importer.DefaultColumns.CustId),
+			},
+		}
+		err error
+	)
+
+	if v := c.Query("dry_run"); v != "" {
+		opt.DryRun, err = strconv.ParseBool(v)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "incorrect dry_run: %v", err)
+		}
+	}
+
+	if v := c.Query("skip_invalid"); v != "" {
+		opt.SkipInvalid, err = strconv.ParseBool(v)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "incorrect skip_invalid: %v", err)
+		}
+	}
+
+	if v := c.Query("batch_size"); v != "" {
+		opt.BatchSize, err = strconv.Atoi(v)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "incorrect batch_size: %v", err)
+		}
+	}
+
+	return opt, nil
+}
+
+// maxImportRows is the most row results kept for an import response, the report counts still cover every row
+const maxImportRows = 1000
+
+// importRows keeps the first maxImportRows row results of an import
+type importRows struct {
+	Rows      []*importer.RowResult
+	Truncated bool
+}
+
+func (r *importRows) add(res *importer.RowResult) {
+	if len(r.Rows) >= maxImportRows {
+		r.Truncated = true
+		return
+	}
+	r.Rows = append(r.Rows, res)
+}
+
+// importUpload imports the file part of a multipart request, passing row results to rows
+func importUpload(
+	c *gin.Context, appV1 phonebook_v1.PhoneBookService, opt *importer.Options, rows *importRows,
+) (*importer.Report, error) {
+	// Stream the file part instead of buffering the whole upload
+	mr, err := c.Request.MultipartReader()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return nil, status.Error(codes.InvalidArgument, "missing file")
+		}
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if part.FormName() != "file" {
+			continue
+		}
+
+		opt.OnRow = rows.add
+		return importer.Import(c.Request.Context(), appV1, part, opt)
+	}
+}
+
+// importErrorCode is the code of an importUpload error, malformed files are invalid arguments
+func importErrorCode(err error) codes.Code {
+	var st interface{ GRPCStatus() *status.Status }
+	switch {
+	case errors.Is(err, importer.ErrMissingColumn), errors.As(err, new(*csv.ParseError)):
+		return codes.InvalidArgument
+	case errors.As(err, &st):
+		return st.GRPCStatus().Code()
+	default:
+		return codes.Internal
+	}
+}
+
+// exportPhones streams the phones selected by req as a file in the format query parameter, csv by default
+func exportPhones(c *gin.Context, appV1 phonebook_v1.PhoneBookService, req *phonebook_v1.ExportPhoneRecordsRequest) {
+	format := c.DefaultQuery("format", exporter.FormatCSV)
+
+	// Headers go first as writers may start writing the file straight away
+	c.Header("Content-Type", exporter.ContentType(format))
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="phones-%s.%s"`, time.Now().UTC().Format("20060102-150405"), format))
+
+	w, err := exporter.NewWriter(format, c.Writer)
+	if err != nil {
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		abortWithError(c, status.Error(codes.InvalidArgument, err.Error()))
+		return
+	}
+
+	err = appV1.ExportPhoneRecords(c.Request.Context(), req, w.Write)
+	if err == nil {
+		err = w.Close()
+	}
+	if err != nil {
+		// Headers are sent with the first row, an incomplete file is all the client can get
+		if !c.Writer.Written() {
+			abortWithError(c, err)
+			return
+		}
+		_ = c.Error(err)
+		c.Abort()
+	}
+}
+
+// abortWithError writes err as json with the http status matching its grpc code
+func abortWithError(c *gin.Context, err error) {
+	st := status.Convert(err)
+	c.AbortWithStatusJSON(httpStatus(st.Code()), gin.H{
+		"code":  st.Code().String(),
+		"error": st.Message(),
+	})
+}
+
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.FailedPrecondition:
+		return http.StatusPreconditionFailed
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Canceled:
+		return 499
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
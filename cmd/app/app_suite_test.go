@@ -0,0 +1,418 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	app_v1 "github.com/gidyon/jumia-exercise/internal/app/v1"
+	"github.com/gidyon/jumia-exercise/internal/importer"
+	"github.com/gidyon/jumia-exercise/internal/repository"
+	phonebook_v1 "github.com/gidyon/jumia-exercise/pkg/api/phonebook/v1"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+)
+
+func TestApp(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "App Suite")
+}
+
+// apiError is the body of failed requests
+type apiError struct {
+	Code  string `json:"code"`
+	Error string `json:"error"`
+}
+
+// importResponse is the body of import requests
+type importResponse struct {
+	Code   string                `json:"code"`
+	Error  string                `json:"error"`
+	Report *importer.Report      `json:"report"`
+	Rows   []*importer.RowResult `json:"rows"`
+}
+
+var _ = Describe("REST API", func() {
+	var router *gin.Engine
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+
+		ctx := context.Background()
+		opt := &app_v1.Options{Repository: repository.NewMemory(), Logger: &zerolog.Logger{}}
+
+		appV1, err := app_v1.NewPhoneBookService(ctx, opt)
+		Expect(err).ShouldNot(HaveOccurred())
+		customersV1, err := app_v1.NewCustomerService(ctx, opt)
+		Expect(err).ShouldNot(HaveOccurred())
+		countriesV1, err := app_v1.NewCountryService(ctx, opt)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		router = gin.New()
+		registerPhonesAPI(router, appV1)
+		registerCustomersAPI(router, customersV1)
+		registerCountriesAPI(router, countriesV1)
+	})
+
+	// serve sends a request with body to the router, values are sent as json
+	serve := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		var r io.Reader
+		switch v := body.(type) {
+		case nil:
+		case string:
+			r = strings.NewReader(v)
+		default:
+			data, err := json.Marshal(v)
+			Expect(err).ShouldNot(HaveOccurred())
+			r = bytes.NewReader(data)
+		}
+
+		req := httptest.NewRequest(method, path, r)
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// decode reads the json body of w into v
+	decode := func(w *httptest.ResponseRecorder, v interface{}) {
+		Expect(json.Unmarshal(w.Body.Bytes(), v)).To(Succeed(), w.Body.String())
+	}
+
+	// expectError checks the status and code of a failed request
+	expectError := func(w *httptest.ResponseRecorder, httpCode int, code codes.Code) {
+		Expect(w.Code).To(Equal(httpCode), w.Body.String())
+		res := &apiError{}
+		decode(w, res)
+		Expect(res.Code).To(Equal(code.String()))
+		Expect(res.Error).ShouldNot(BeEmpty())
+	}
+
+	createPhone := func(pr *phonebook_v1.PhoneRecord) *phonebook_v1.PhoneRecord {
+		w := serve(http.MethodPost, "/api/v1/phones", pr)
+		Expect(w.Code).To(Equal(http.StatusCreated), w.Body.String())
+		res := &phonebook_v1.PhoneRecord{}
+		decode(w, res)
+		return res
+	}
+
+	DescribeTable("Mapping grpc codes to http statuses",
+		func(code codes.Code, httpCode int) {
+			Expect(httpStatus(code)).To(Equal(httpCode))
+		},
+		Entry("OK", codes.OK, http.StatusOK),
+		Entry("InvalidArgument", codes.InvalidArgument, http.StatusBadRequest),
+		Entry("OutOfRange", codes.OutOfRange, http.StatusBadRequest),
+		Entry("FailedPrecondition", codes.FailedPrecondition, http.StatusPreconditionFailed),
+		Entry("Unauthenticated", codes.Unauthenticated, http.StatusUnauthorized),
+		Entry("PermissionDenied", codes.PermissionDenied, http.StatusForbidden),
+		Entry("NotFound", codes.NotFound, http.StatusNotFound),
+		Entry("AlreadyExists", codes.AlreadyExists, http.StatusConflict),
+		Entry("Aborted", codes.Aborted, http.StatusConflict),
+		Entry("ResourceExhausted", codes.ResourceExhausted, http.StatusTooManyRequests),
+		Entry("Canceled", codes.Canceled, 499),
+		Entry("Unimplemented", codes.Unimplemented, http.StatusNotImplemented),
+		Entry("Unavailable", codes.Unavailable, http.StatusServiceUnavailable),
+		Entry("DeadlineExceeded", codes.DeadlineExceeded, http.StatusGatewayTimeout),
+		Entry("Internal", codes.Internal, http.StatusInternalServerError),
+		Entry("Unknown", codes.Unknown, http.StatusInternalServerError),
+		Entry("DataLoss", codes.DataLoss, http.StatusInternalServerError),
+	)
+
+	Context("Managing phone records", func() {
+		It("should create, get, update, list and delete a phone record", func() {
+			created := createPhone(&phonebook_v1.PhoneRecord{CountryName: "Cameroon", Number: "(237) 697151594"})
+			Expect(created.Id).ShouldNot(BeEmpty())
+			Expect(created.NumberE164).To(Equal("+237697151594"))
+			Expect(created.PhoneValid).To(BeTrue())
+
+			w := serve(http.MethodGet, "/api/v1/phones/"+created.Id, nil)
+			Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+			got := &phonebook_v1.PhoneRecord{}
+			decode(w, got)
+			Expect(got.Number).To(Equal(created.Number))
+
+			w = serve(http.MethodPatch, "/api/v1/phones/"+created.Id, &phonebook_v1.UpdatePhoneRecordRequest{
+				PhoneRecord: &phonebook_v1.PhoneRecord{Number: "(237) 677046616"},
+				UpdateMask:  []string{phonebook_v1.UpdateMaskNumber},
+			})
+			Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+			updated := &phonebook_v1.PhoneRecord{}
+			decode(w, updated)
+			Expect(updated.Id).To(Equal(created.Id))
+			Expect(updated.NumberE164).To(Equal("+237677046616"))
+
+			w = serve(http.MethodGet, "/api/v1/phones?country_code=237&valid_only=true", nil)
+			Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+			list := &phonebook_v1.ListPhoneRecordsResponse{}
+			decode(w, list)
+			Expect(list.PhoneRecords).To(HaveLen(1))
+			Expect(list.PhoneRecords[0].Id).To(Equal(created.Id))
+
+			w = serve(http.MethodDelete, "/api/v1/phones/"+created.Id+"?deleted_by=admin", nil)
+			Expect(w.Code).To(Equal(http.StatusNoContent), w.Body.String())
+
+			expectError(serve(http.MethodGet, "/api/v1/phones/"+created.Id, nil), http.StatusNotFound, codes.NotFound)
+		})
+
+		It("should reject incorrect phone record requests", func() {
+			expectError(serve(http.MethodPost, "/api/v1/phones", "{"), http.StatusBadRequest, codes.InvalidArgument)
+			expectError(
+				serve(http.MethodPost, "/api/v1/phones", &phonebook_v1.PhoneRecord{CountryName: "Cameroon"}),
+				http.StatusBadRequest, codes.InvalidArgument,
+			)
+			expectError(serve(http.MethodGet, "/api/v1/phones/abc", nil), http.StatusNotFound, codes.NotFound)
+			expectError(
+				serve(http.MethodPatch, "/api/v1/phones/1", &phonebook_v1.UpdatePhoneRecordRequest{}),
+				http.StatusBadRequest, codes.InvalidArgument,
+			)
+			expectError(serve(http.MethodGet, "/api/v1/phones?valid_only=maybe", nil), http.StatusBadRequest, codes.InvalidArgument)
+			expectError(serve(http.MethodGet, "/api/v1/phones?page_size=ten", nil), http.StatusBadRequest, codes.InvalidArgument)
+		})
+
+		It("should validate a number without saving it", func() {
+			w := serve(http.MethodPost, "/api/v1/phones/validate", &phonebook_v1.ValidatePhoneNumberRequest{
+				Number: "(237) 697151594", CountryName: "cameroon",
+			})
+			Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+			res := &phonebook_v1.ValidatePhoneNumberResponse{}
+			decode(w, res)
+			Expect(res.NumberE164).To(Equal("+237697151594"))
+			Expect(res.Validation.Valid).To(BeTrue())
+			Expect(res.Validation.CountryName).To(Equal("Cameroon"))
+
+			expectError(serve(http.MethodPost, "/api/v1/phones/validate", "[]"), http.StatusBadRequest, codes.InvalidArgument)
+			expectError(
+				serve(http.MethodPost, "/api/v1/phones/validate", &phonebook_v1.ValidatePhoneNumberRequest{}),
+				http.StatusBadRequest, codes.InvalidArgument,
+			)
+		})
+
+		It("should create and delete phone records in batches", func() {
+			w := serve(http.MethodPost, "/api/v1/phones/batch_create", &phonebook_v1.BatchCreatePhoneRecordsRequest{
+				PhoneRecords: []*phonebook_v1.PhoneRecord{
+					{CountryName: "Cameroon", Number: "(237) 697151594"},
+					{Number: "12345"},
+				},
+				BestEffort: true,
+			})
+			Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+			created := &phonebook_v1.BatchPhoneRecordsResponse{}
+			decode(w, created)
+			Expect(created.SucceededCount).To(BeEquivalentTo(1))
+			Expect(created.FailedCount).To(BeEquivalentTo(1))
+			Expect(created.Results[1].Code).To(Equal(codes.InvalidArgument.String()))
+
+			w = serve(http.MethodPost, "/api/v1/phones/batch_delete", &phonebook_v1.BatchDeletePhoneRecordsRequest{
+				RecordIds:  []string{created.Results[0].RecordId, "404"},
+				BestEffort: true,
+			})
+			Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+			deleted := &phonebook_v1.BatchPhoneRecordsResponse{}
+			decode(w, deleted)
+			Expect(deleted.SucceededCount).To(BeEquivalentTo(1))
+			Expect(deleted.Results[1].Code).To(Equal(codes.NotFound.String()))
+
+			expectError(serve(http.MethodPost, "/api/v1/phones/batch_create", "{"), http.StatusBadRequest, codes.InvalidArgument)
+			expectError(
+				serve(http.MethodPost, "/api/v1/phones/batch_delete", &phonebook_v1.BatchDeletePhoneRecordsRequest{}),
+				http.StatusBadRequest, codes.InvalidArgument,
+			)
+		})
+
+		It("should list and restore deleted phone records", func() {
+			created := createPhone(&phonebook_v1.PhoneRecord{CountryName: "Uganda", Number: "(256) 775069443"})
+			w := serve(http.MethodDelete, "/api/v1/phones/"+created.Id, nil)
+			Expect(w.Code).To(Equal(http.StatusNoContent), w.Body.String())
+
+			w = serve(http.MethodGet, "/api/v1/deleted_phones", nil)
+			Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+			list := &phonebook_v1.ListPhoneRecordsResponse{}
+			decode(w, list)
+			Expect(list.PhoneRecords).To(HaveLen(1))
+			Expect(list.PhoneRecords[0].Id).To(Equal(created.Id))
+
+			w = serve(http.MethodPost, "/api/v1/deleted_phones/"+created.Id+"/restore", nil)
+			Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+			Expect(serve(http.MethodGet, "/api/v1/phones/"+created.Id, nil).Code).To(Equal(http.StatusOK))
+
+			expectError(
+				serve(http.MethodPost, "/api/v1/deleted_phones/"+created.Id+"/restore", nil),
+				http.StatusNotFound, codes.NotFound,
+			)
+			expectError(serve(http.MethodGet, "/api/v1/deleted_phones?not_valid_only=x", nil), http.StatusBadRequest, codes.InvalidArgument)
+		})
+	})
+
+	Context("Importing phone records", func() {
+		// upload sends a csv file as the file part of a multipart form
+		upload := func(query, csv string) (*httptest.ResponseRecorder, *importResponse) {
+			body := &bytes.Buffer{}
+			mw := multipart.NewWriter(body)
+			part, err := mw.CreateFormFile("file", "phones.csv")
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = part.Write([]byte(csv))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(mw.Close()).To(Succeed())
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/phones/import"+query, body)
+			req.Header.Set("Content-Type", mw.FormDataContentType())
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			res := &importResponse{}
+			decode(w, res)
+			return w, res
+		}
+
+		It("should import the accepted rows and report every row", func() {
+			w, res := upload("", "country,phone\nCameroon,(237) 697151594\nNowhere,12345\n")
+			Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+			Expect(res.Report.TotalRows).To(Equal(2))
+			Expect(res.Report.CreatedRows).To(Equal(1))
+			Expect(res.Report.RejectedRows).To(Equal(1))
+			Expect(res.Rows).To(HaveLen(2))
+			Expect(res.Rows[0].RecordId).ShouldNot(BeEmpty())
+
+			w = serve(http.MethodGet, "/api/v1/phones/"+res.Rows[0].RecordId, nil)
+			Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+		})
+
+		It("should reject incorrect imports", func() {
+			w, res := upload("?dry_run=sometimes", "country,phone\n")
+			Expect(w.Code).To(Equal(http.StatusBadRequest), w.Body.String())
+			Expect(res.Code).To(Equal(codes.InvalidArgument.String()))
+
+			w, res = upload("?phone_column=number", "country,phone\nCameroon,(237) 697151594\n")
+			Expect(w.Code).To(Equal(http.StatusBadRequest), w.Body.String())
+			Expect(res.Code).To(Equal(codes.InvalidArgument.String()))
+			Expect(res.Error).To(ContainSubstring(importer.ErrMissingColumn.Error()))
+
+			expectError(serve(http.MethodPost, "/api/v1/phones/import", "{}"), http.StatusBadRequest, codes.InvalidArgument)
+		})
+	})
+
+	Context("Exporting phone records", func() {
+		It("should stream the records as a file", func() {
+			createPhone(&phonebook_v1.PhoneRecord{CountryName: "Cameroon", Number: "(237) 697151594"})
+
+			w := serve(http.MethodGet, "/api/v1/export/phones?country_code=237", nil)
+			Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+			Expect(w.Header().Get("Content-Disposition")).To(HaveSuffix(`.csv"`))
+			Expect(w.Body.String()).To(ContainSubstring("+237697151594"))
+		})
+
+		It("should reject unknown formats before sending a file", func() {
+			w := serve(http.MethodGet, "/api/v1/export/phones?format=pdf", nil)
+			expectError(w, http.StatusBadRequest, codes.InvalidArgument)
+			Expect(w.Header().Get("Content-Disposition")).To(BeEmpty())
+		})
+	})
+
+	Context("Managing customers", func() {
+		It("should create, get, update, list and delete a customer", func() {
+			w := serve(http.MethodPost, "/api/v1/customers", &phonebook_v1.Customer{Name: "Jane", Email: "jane@example.com"})
+			Expect(w.Code).To(Equal(http.StatusCreated), w.Body.String())
+			created := &phonebook_v1.Customer{}
+			decode(w, created)
+			Expect(created.Id).ShouldNot(BeEmpty())
+
+			w = serve(http.MethodGet, "/api/v1/customers/"+created.Id, nil)
+			Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+
+			w = serve(http.MethodPatch, "/api/v1/customers/"+created.Id, &phonebook_v1.UpdateCustomerRequest{
+				Customer:   &phonebook_v1.Customer{Name: "Jane Doe"},
+				UpdateMask: []string{"name"},
+			})
+			Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+			updated := &phonebook_v1.Customer{}
+			decode(w, updated)
+			Expect(updated.Name).To(Equal("Jane Doe"))
+
+			createPhone(&phonebook_v1.PhoneRecord{CountryName: "Cameroon", Number: "(237) 697151594", CustId: created.Id})
+			w = serve(http.MethodGet, "/api/v1/customers/"+created.Id+"/phones", nil)
+			Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+			phones := &phonebook_v1.ListPhoneRecordsResponse{}
+			decode(w, phones)
+			Expect(phones.PhoneRecords).To(HaveLen(1))
+
+			w = serve(http.MethodGet, "/api/v1/customers?page_size=10", nil)
+			Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+			list := &phonebook_v1.ListCustomersResponse{}
+			decode(w, list)
+			Expect(list.Customers).To(HaveLen(1))
+
+			// Customers owning phone records are kept
+			expectError(serve(http.MethodDelete, "/api/v1/customers/"+created.Id, nil), http.StatusPreconditionFailed, codes.FailedPrecondition)
+		})
+
+		It("should reject incorrect customer requests", func() {
+			expectError(serve(http.MethodPost, "/api/v1/customers", "{"), http.StatusBadRequest, codes.InvalidArgument)
+			expectError(serve(http.MethodGet, "/api/v1/customers/404", nil), http.StatusNotFound, codes.NotFound)
+			expectError(serve(http.MethodGet, "/api/v1/customers?page_size=x", nil), http.StatusBadRequest, codes.InvalidArgument)
+			expectError(serve(http.MethodDelete, "/api/v1/customers/404", nil), http.StatusNotFound, codes.NotFound)
+		})
+	})
+
+	Context("Managing countries", func() {
+		It("should create, get, update, list and disable a country", func() {
+			w := serve(http.MethodPost, "/api/v1/countries", &phonebook_v1.Country{
+				CountryName: "Wakanda", CountryCode: 998, Patterns: []string{`^\(998\)\ ?[5-9]\d{8}$`},
+			})
+			Expect(w.Code).To(Equal(http.StatusCreated), w.Body.String())
+			created := &phonebook_v1.Country{}
+			decode(w, created)
+			Expect(created.Id).ShouldNot(BeEmpty())
+
+			w = serve(http.MethodGet, "/api/v1/countries/"+created.Id, nil)
+			Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+
+			w = serve(http.MethodPatch, "/api/v1/countries/"+created.Id, &phonebook_v1.UpdateCountryRequest{
+				Country:    &phonebook_v1.Country{IsoCode: "wk"},
+				UpdateMask: []string{phonebook_v1.UpdateMaskIsoCode},
+			})
+			Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+			updated := &phonebook_v1.Country{}
+			decode(w, updated)
+			Expect(updated.IsoCode).To(Equal("WK"))
+
+			w = serve(http.MethodPost, "/api/v1/countries/"+created.Id+"/disable", nil)
+			Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+
+			w = serve(http.MethodGet, "/api/v1/countries?enabled_only=true", nil)
+			Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+			list := &phonebook_v1.ListCountriesResponse{}
+			decode(w, list)
+			for _, country := range list.Countries {
+				Expect(country.Id).NotTo(Equal(created.Id))
+			}
+		})
+
+		It("should reject incorrect country requests", func() {
+			country := &phonebook_v1.Country{CountryName: "Wakanda", CountryCode: 998, Patterns: []string{`^\d+$`}}
+			Expect(serve(http.MethodPost, "/api/v1/countries", country).Code).To(Equal(http.StatusCreated))
+			expectError(serve(http.MethodPost, "/api/v1/countries", country), http.StatusConflict, codes.AlreadyExists)
+
+			expectError(serve(http.MethodPost, "/api/v1/countries", "{"), http.StatusBadRequest, codes.InvalidArgument)
+			expectError(serve(http.MethodGet, "/api/v1/countries/404", nil), http.StatusNotFound, codes.NotFound)
+			expectError(
+				serve(http.MethodPatch, "/api/v1/countries/404", &phonebook_v1.UpdateCountryRequest{}),
+				http.StatusBadRequest, codes.InvalidArgument,
+			)
+			expectError(serve(http.MethodGet, "/api/v1/countries?enabled_only=x", nil), http.StatusBadRequest, codes.InvalidArgument)
+			expectError(serve(http.MethodPost, "/api/v1/countries/404/disable", nil), http.StatusNotFound, codes.NotFound)
+		})
+	})
+})
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	phonebook_v1 "github.com/gidyon/jumia-exercise/pkg/api/phonebook/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// registerCountriesPage adds the admin page for managing supported countries and their validation rules
+func registerCountriesPage(router gin.IRouter, countriesV1 phonebook_v1.CountryService, log *zerolog.Logger) {
+	// Failed changes are shown on the page instead of an empty error response
+	redirectWithError := func(c *gin.Context, err error) {
+		log.Error().Msg(err.Error())
+		c.Redirect(http.StatusFound, "/countries?error="+url.QueryEscape(status.Convert(err).Message()))
+	}
+
+	router.GET("/countries", func(c *gin.Context) {
+		var (
+			editId      = c.Query("editId")
+			editCountry *phonebook_v1.Country
+		)
+
+		// Get all countries, disabled ones can be enabled again
+		res, err := countriesV1.ListCountries(c.Request.Context(), &phonebook_v1.ListCountriesRequest{})
+		if err != nil {
+			c.AbortWithStatus(httpStatus(status.Code(err)))
+			return
+		}
+
+		// Country being edited
+		if editId != "" {
+			editCountry, err = countriesV1.GetCountry(c.Request.Context(), &phonebook_v1.GetCountryRequest{
+				CountryId: editId,
+			})
+			if err != nil {
+				c.AbortWithStatus(httpStatus(status.Code(err)))
+				return
+			}
+		}
+
+		// Render HTML
+		c.HTML(http.StatusOK, "countries.html", gin.H{
+			"countries":   res.Countries,
+			"editCountry": editCountry,
+			"error":       c.Query("error"),
+		})
+	})
+
+	router.POST("/addCountry", func(c *gin.Context) {
+		country, err := countryFromForm(c)
+		if err != nil {
+			redirectWithError(c, err)
+			return
+		}
+
+		// Create country
+		_, err = countriesV1.CreateCountry(c.Request.Context(), country)
+		if err != nil {
+			redirectWithError(c, err)
+			return
+		}
+
+		c.Redirect(http.StatusFound, "/countries")
+	})
+
+	router.POST("/updateCountry", func(c *gin.Context) {
+		country, err := countryFromForm(c)
+		if err != nil {
+			redirectWithError(c, err)
+			return
+		}
+		country.Id = c.PostForm("id")
+
+		// Update country, the form always sends every rule field
+		_, err = countriesV1.UpdateCountry(c.Request.Context(), &phonebook_v1.UpdateCountryRequest{
+			Country: country,
+			UpdateMask: []string{
+				phonebook_v1.UpdateMaskCountryName,
+				phonebook_v1.UpdateMaskCountryCode,
+				phonebook_v1.UpdateMaskIsoCode,
+				phonebook_v1.UpdateMaskTrunkPrefix,
+				phonebook_v1.UpdateMaskPatterns,
+				phonebook_v1.UpdateMaskMinLength,
+				phonebook_v1.UpdateMaskMaxLength,
+				phonebook_v1.UpdateMaskNumberTypes,
+				phonebook_v1.UpdateMaskOperators,
+			},
+		})
+		if err != nil {
+			redirectWithError(c, err)
+			return
+		}
+
+		c.Redirect(http.StatusFound, "/countries")
+	})
+
+	router.POST("/disableCountry", func(c *gin.Context) {
+		_, err := countriesV1.DisableCountry(c.Request.Context(), &phonebook_v1.DisableCountryRequest{
+			CountryId: c.PostForm("id"),
+		})
+		if err != nil {
+			redirectWithError(c, err)
+			return
+		}
+
+		c.Redirect(http.StatusFound, "/countries")
+	})
+
+	router.POST("/enableCountry", func(c *gin.Context) {
+		_, err := countriesV1.UpdateCountry(c.Request.Context(), &phonebook_v1.UpdateCountryRequest{
+			Country:    &phonebook_v1.Country{Id: c.PostForm("id"), Disabled: false},
+			UpdateMask: []string{phonebook_v1.UpdateMaskDisabled},
+		})
+		if err != nil {
+			redirectWithError(c, err)
+			return
+		}
+
+		c.Redirect(http.StatusFound, "/countries")
+	})
+}
+
+// countryFromForm reads the country form, patterns are one per line and prefixes are lines like "MOBILE: 7, 82-87"
+func countryFromForm(c *gin.Context) (*phonebook_v1.Country, error) {
+	country := &phonebook_v1.Country{
+		CountryName: c.PostForm("country"),
+		IsoCode:     c.PostForm("isoCode"),
+		TrunkPrefix: c.PostForm("trunkPrefix"),
+		Patterns:    formLines(c.PostForm("patterns")),
+	}
+
+	numbers := map[string]*int{"minLength": &country.MinLength, "maxLength": &country.MaxLength}
+	for key, value := range numbers {
+		if v := strings.TrimSpace(c.PostForm(key)); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "incorrect %s %q", key, v)
+			}
+			*value = n
+		}
+	}
+
+	if v := strings.TrimSpace(c.PostForm("countryCode")); v != "" {
+		code, err := strconv.ParseUint(strings.TrimPrefix(v, "+"), 10, 32)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "incorrect dial code %q", v)
+		}
+		country.CountryCode = uint(code)
+	}
+
+	var err error
+	country.NumberTypes, err = prefixesFromForm(c.PostForm("numberTypes"))
+	if err != nil {
+		return nil, err
+	}
+	country.Operators, err = prefixesFromForm(c.PostForm("operators"))
+	if err != nil {
+		return nil, err
+	}
+
+	return country, nil
+}
+
+// formLines splits a textarea into its non empty lines
+func formLines(text string) []string {
+	lines := make([]string, 0, 1)
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// prefixesFromForm reads lines like "MOBILE: 7, 82-87" into a map of prefixes
+func prefixesFromForm(text string) (map[string][]string, error) {
+	lines := formLines(text)
+	if len(lines) == 0 {
+		return nil, nil
+	}
+
+	prefixes := make(map[string][]string, len(lines))
+	for _, line := range lines {
+		parts := strings.SplitN(line, ":", 2)
+		name := strings.TrimSpace(parts[0])
+		if len(parts) != 2 || name == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "incorrect prefixes line %q, use \"NAME: 7, 82-87\"", line)
+		}
+		for _, prefix := range strings.Split(parts[1], ",") {
+			if prefix = strings.TrimSpace(prefix); prefix != "" {
+				prefixes[name] = append(prefixes[name], prefix)
+			}
+		}
+	}
+
+	return prefixes, nil
+}
+
+// prefixLines writes a map of prefixes the way prefixesFromForm reads it
+func prefixLines(prefixes map[string][]string) string {
+	names := make([]string, 0, len(prefixes))
+	for name := range prefixes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := make([]string, 0, len(names))
+	for _, name := range names {
+		lines = append(lines, fmt.Sprintf("%s: %s", name, strings.Join(prefixes[name], ", ")))
+	}
+	return strings.Join(lines, "\n")
+}
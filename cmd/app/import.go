@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	app_v1 "github.com/gidyon/jumia-exercise/internal/app/v1"
+	"github.com/gidyon/jumia-exercise/internal/database"
+	"github.com/gidyon/jumia-exercise/internal/importer"
+	"github.com/rs/zerolog"
+)
+
+// runImport imports phone records from a CSV file into the database used by the server
+func runImport(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: app import [flags] <file.csv | ->")
+		fs.PrintDefaults()
+	}
+
+	var (
+		dryRun        = fs.Bool("dryRun", false, "Validate rows and print the report without saving them")
+		skipInvalid   = fs.Bool("skipInvalid", false, "Reject rows with numbers that fail validation instead of saving them as not valid")
+		batchSize     = fs.Int("batchSize", importer.DefaultBatchSize, "Number of accepted rows committed together")
+		countryColumn = fs.String("countryColumn", importer.DefaultColumns.Country, "Header of the country column")
+		phoneColumn   = fs.String("phoneColumn", importer.DefaultColumns.Phone, "Header of the phone column")
+		custIdColumn  = fs.String("custIdColumn", importer.DefaultColumns.CustId, "Header of the customer id column")
+		reportPath    = fs.String("report", "", "Path to write the per row report as CSV, stdout is used when empty")
+	)
+	fs.StringVar(rules, "rules", "", "Path to a YAML or JSON country rules file, built-in rules are used when empty")
+	fs.BoolVar(rulesFromDB, "rulesFromDB", false, "Whether to load country rules from the countries table")
+	fs.StringVar(dbDriver, "dbDriver", database.DriverSQLite, "Database driver, one of sqlite, postgres or mysql")
+	fs.StringVar(dsn, "dsn", "phones.db", "Database connection string, a file path for sqlite")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("missing csv file")
+	}
+
+	var in io.Reader = os.Stdin
+	if name := fs.Arg(0); name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	var out io.Writer = os.Stdout
+	if *reportPath != "" {
+		f, err := os.Create(*reportPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	ctx := context.Background()
+
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+
+	if err := checkSchema(db); err != nil {
+		return err
+	}
+
+	if err := loadRules(db); err != nil {
+		return err
+	}
+
+	appV1, err := app_v1.NewPhoneBookService(ctx, &app_v1.Options{
+		SqlDB:  db,
+		Logger: &log,
+	})
+	if err != nil {
+		return err
+	}
+
+	w := csv.NewWriter(out)
+	defer w.Flush()
+
+	err = w.Write([]string{"row", "status", "country", "phone", "e164", "valid", "reasons", "record_id", "error"})
+	if err != nil {
+		return err
+	}
+
+	report, err := importer.Import(ctx, appV1, in, &importer.Options{
+		Columns: &importer.Columns{
+			Country: *countryColumn,
+			Phone:   *phoneColumn,
+			CustId:  *custIdColumn,
+		},
+		DryRun:      *dryRun,
+		SkipInvalid: *skipInvalid,
+		BatchSize:   *batchSize,
+		OnRow: func(res *importer.RowResult) {
+			_ = w.Write([]string{
+				strconv.Itoa(res.Row),
+				res.Status,
+				res.CountryName,
+				res.Number,
+				res.NumberE164,
+				strconv.FormatBool(res.Valid),
+				strings.Join(res.Reasons, ","),
+				res.RecordId,
+				res.Error,
+			})
+		},
+	})
+	if report != nil {
+		fmt.Fprintf(os.Stderr, "rows: %d, accepted: %d, created: %d, rejected: %d, failed: %d, dry run: %v\n",
+			report.TotalRows, report.AcceptedRows, report.CreatedRows, report.RejectedRows, report.FailedRows, report.DryRun)
+	}
+	if err != nil {
+		return err
+	}
+
+	w.Flush()
+	return w.Error()
+}
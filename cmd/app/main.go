@@ -2,32 +2,65 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"html/template"
 	"math/rand"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
 	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
 	randomdata "github.com/Pallinder/go-randomdata"
 	app_v1 "github.com/gidyon/jumia-exercise/internal/app/v1"
+	"github.com/gidyon/jumia-exercise/internal/database"
 	"github.com/gidyon/jumia-exercise/internal/models"
 	phonebook_v1 "github.com/gidyon/jumia-exercise/pkg/api/phonebook/v1"
+	"github.com/gidyon/jumia-exercise/pkg/api/phonebook/v1/phonebookpb"
 	"github.com/gidyon/jumia-exercise/pkg/utils/phoneutils"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
-	"gorm.io/driver/sqlite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
 )
 
 var (
-	port  = flag.String("port", ":8080", "Port for server")
-	debug = flag.Bool("debug", true, "Whether to run server in debug mode, will also set some default data")
+	port            = flag.String("port", ":8080", "Port for server")
+	dbDriver        = flag.String("dbDriver", database.DriverSQLite, "Database driver, one of sqlite, postgres or mysql")
+	dsn             = flag.String("dsn", "phones.db", "Database connection string, a file path for sqlite")
+	grpcPort        = flag.String("grpcPort", ":9090", "Port for gRPC server")
+	debug           = flag.Bool("debug", true, "Whether to run server in debug mode, will also set some default data")
+	rules           = flag.String("rules", "", "Path to a YAML or JSON country rules file, built-in rules are used when empty")
+	rulesFromDB     = flag.Bool("rulesFromDB", false, "Whether to load country rules from the countries table")
+	pageTokenSecret = flag.String("pageTokenSecret", "", "Secret signing page tokens, replicas must share it, a random secret is used when empty")
+	trashRetention  = flag.Duration("trashRetention", 30*24*time.Hour, "How long deleted phone records are kept before being purged, 0 keeps them forever")
 )
 
 func main() {
+	// Subcommands
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		if err := runImport(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	flag.Parse()
 
 	ctx := context.Background()
@@ -47,59 +80,139 @@ func main() {
 	rand.Seed(time.Now().UnixNano())
 
 	// Db connection (Pool)
-	db, err := gorm.Open(sqlite.Open("phones.db"), &gorm.Config{})
+	db, err := openDB()
 	handleError(err)
 
+	// The schema is changed by the migrate subcommand only, an outdated schema would fail queries at random
+	if err := checkSchema(db); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
 	if *debug {
 		db = db.Debug()
 
 		gin.SetMode(gin.DebugMode)
 
-		// This block is for easier demostration purposes as it performs auto-migrations and populates data each time service starts
+		// This block is for easier demostration purposes as it populates an empty database when service starts
 		// It is not intended for a serious production application
 		{
-			// Drop all tables
-			handleError(db.Migrator().DropTable(&models.Country{}, &models.Phone{}))
-
-			// Auto migrate
-			handleError(db.Migrator().AutoMigrate(&models.Country{}, &models.Phone{}))
+			var countries int64
+			handleError(db.Model(&models.Country{}).Count(&countries).Error)
 
-			// Add countries
-			handleError(addCounties(db))
+			if countries == 0 {
+				// Add countries
+				handleError(addCounties(db))
 
-			// Add phones
-			handleError(addRandomPhones(db))
+				// Add phones
+				handleError(addRandomPhones(db))
+			}
 		}
 	}
 
+	// Country rules, reloaded on SIGHUP
+	handleError(loadRules(db))
+
+	go func() {
+		sigs := make(chan os.Signal, 1)
+		signal.Notify(sigs, syscall.SIGHUP)
+		for range sigs {
+			if err := loadRules(db); err != nil {
+				log.Error().Str("error", err.Error()).Msg("failed to reload country rules")
+				continue
+			}
+			log.Info().Str("version", phoneutils.DefaultRegistry.Version()).Msg("country rules reloaded")
+		}
+	}()
+
 	// Singleton instance of phone book service
 	appV1, err := app_v1.NewPhoneBookService(ctx, &app_v1.Options{
+		SqlDB:           db,
+		Logger:          &log,
+		TrashRetention:  *trashRetention,
+		PageTokenSecret: []byte(*pageTokenSecret),
+	})
+	handleError(err)
+
+	customersV1, err := app_v1.NewCustomerService(ctx, &app_v1.Options{
+		SqlDB:           db,
+		Logger:          &log,
+		PageTokenSecret: []byte(*pageTokenSecret),
+	})
+	handleError(err)
+
+	// Validation picks up added countries, and every country change when rules are loaded from the countries table
+	countriesV1, err := app_v1.NewCountryService(ctx, &app_v1.Options{
 		SqlDB:  db,
 		Logger: &log,
+		ReloadRules: func() error {
+			return loadRules(db)
+		},
 	})
 	handleError(err)
 
+	// gRPC server
+	lis, err := net.Listen("tcp", *grpcPort)
+	handleError(err)
+
+	grpcServer := grpc.NewServer()
+	phonebookpb.RegisterPhoneBookServiceServer(grpcServer, app_v1.NewPhoneBookGRPCServer(appV1))
+	phonebookpb.RegisterCustomerServiceServer(grpcServer, app_v1.NewCustomerGRPCServer(customersV1))
+	reflection.Register(grpcServer)
+
+	go func() {
+		handleError(grpcServer.Serve(lis))
+	}()
+
 	router := gin.Default()
 
 	router.SetFuncMap(template.FuncMap{
 		"toString": func(v interface{}) string {
 			return fmt.Sprint(v)
 		},
+		"lines": func(lines []string) string {
+			return strings.Join(lines, "\n")
+		},
+		"prefixLines": prefixLines,
 	})
 
 	router.LoadHTMLGlob("../../web/templates/*")
 
+	router.POST("/updatePhone", func(c *gin.Context) {
+		// Update record, the form always sends every editable field
+		_, err := appV1.UpdatePhoneRecord(c.Request.Context(), &phonebook_v1.UpdatePhoneRecordRequest{
+			PhoneRecord: &phonebook_v1.PhoneRecord{
+				Id:          c.PostForm("id"),
+				CustId:      c.PostForm("custId"),
+				CountryName: c.PostForm("country"),
+				Number:      c.PostForm("phone"),
+			},
+			UpdateMask: []string{
+				phonebook_v1.UpdateMaskNumber, phonebook_v1.UpdateMaskCountry, phonebook_v1.UpdateMaskCustId,
+			},
+		})
+		if err != nil {
+			log.Error().Msg(err.Error())
+			c.AbortWithStatus(httpStatus(status.Code(err)))
+			return
+		}
+
+		// Redirect to home
+		c.Redirect(http.StatusFound, "/")
+	})
+
 	router.POST("/addPhone", func(c *gin.Context) {
 		var (
 			// Pagination variables
 			countryName = c.PostForm("country")
 			number      = c.PostForm("phone")
+			custId      = c.PostForm("custId")
 			err         error
 		)
 
 		// Create record
 		_, err = appV1.CreatePhoneRecord(c.Request.Context(), &phonebook_v1.PhoneRecord{
-			CustId:      "",
+			CustId:      custId,
 			CountryName: countryName,
 			CountryCode: 0,
 			Number:      number,
@@ -107,7 +220,7 @@ func main() {
 		})
 		if err != nil {
 			log.Error().Msg(err.Error())
-			c.AbortWithStatus(http.StatusInternalServerError)
+			c.AbortWithStatus(httpStatus(status.Code(err)))
 			return
 		}
 
@@ -115,32 +228,83 @@ func main() {
 		c.Redirect(http.StatusFound, "/")
 	})
 
-	// Pagination API
-	pagination := phoneutils.NewPaginationAPI()
+	router.GET("/validatePhone", func(c *gin.Context) {
+		// Validate without saving
+		res, err := appV1.ValidatePhoneNumber(c.Request.Context(), &phonebook_v1.ValidatePhoneNumberRequest{
+			Number:      c.Query("phone"),
+			CountryName: c.Query("country"),
+		})
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, res)
+	})
+
+	router.GET("/exportPhones", func(c *gin.Context) {
+		// Export what the index page shows with the same filters and order
+		exportPhones(c, appV1, &phonebook_v1.ExportPhoneRecordsRequest{
+			Filters: indexFilters(c),
+			OrderBy: c.Query("orderBy"),
+		})
+	})
+
+	router.POST("/importPhones", func(c *gin.Context) {
+		opt, err := importOptionsFromQuery(c)
+		if err != nil {
+			c.AbortWithStatus(httpStatus(status.Code(err)))
+			return
+		}
+
+		// Render the per row report, failed imports show how far they got
+		rows := &importRows{}
+		report, err := importUpload(c, appV1, opt, rows)
+		code := codes.OK
+		if err != nil {
+			log.Error().Msg(err.Error())
+			code = importErrorCode(err)
+		}
+
+		c.HTML(httpStatus(code), "import.html", gin.H{
+			"report":    report,
+			"rows":      rows.Rows,
+			"truncated": rows.Truncated,
+			"maxRows":   maxImportRows,
+			"error":     err,
+		})
+	})
+
+	// JSON API
+	registerPhonesAPI(router, appV1)
+	registerCustomersAPI(router, customersV1)
+	registerCountriesAPI(router, countriesV1)
+
+	// Admin pages
+	registerCountriesPage(router, countriesV1, &log)
 
 	router.GET("/", func(c *gin.Context) {
 		var (
-			// Pagination variables
-			prevPageToken = c.Query("prevPageToken")
-			nextPageToken = c.Query("nextPageToken")
-			pageSize      = c.Query("pageSize")
-			sessionId     = c.Query("sessionId")
-			pageSizeInt   = 20
-			pageInfo      = &phoneutils.PageInfo{}
+			// Pagination variables, page tokens hold the whole pagination state
+			pageToken   = c.Query("pageToken")
+			pageSize    = c.Query("pageSize")
+			pageSizeInt = 20
+			orderBy     = c.Query("orderBy")
 
 			// Filters in query parameters
 			countryCodeFilter = c.Query("countryCodeFilter")
 			validStateFilter  = c.Query("validStateFilter")
 			phoneFilter       = c.Query("phoneFilter")
+			phoneMatchFilter  = c.Query("phoneMatchFilter")
+			numberTypeFilter  = c.Query("numberTypeFilter")
+			editId            = c.Query("editId")
+			editPhone         *phonebook_v1.PhoneRecord
+			operatorFilter    = c.Query("operatorFilter")
+			custIdFilter      = c.Query("custIdFilter")
+			createdFromFilter = c.Query("createdFromFilter")
+			createdToFilter   = c.Query("createdToFilter")
 		)
 
-		// Page token
-		if prevPageToken != "" {
-			pageInfo = pagination.GetBackPageInfo(sessionId, prevPageToken)
-		} else {
-			pageInfo.PageToken = nextPageToken
-		}
-
 		// Page size
 		if pageSize != "" {
 			pageSizeInt, err = strconv.Atoi(pageSize)
@@ -151,109 +315,234 @@ func main() {
 		}
 
 		// Get phone numbers
-		listRes, err := appV1.ListPhoneRecords(c.Request.Context(), &phonebook_v1.ListPhoneRecordsRequest{
+		listReq := &phonebook_v1.ListPhoneRecordsRequest{
 			PageSize:  int32(pageSizeInt),
-			PageToken: pageInfo.PageToken,
-			Filters: &phonebook_v1.PhoneRecordsFilters{
-				CountryCode:  countryCodeFilter,
-				ValidOnly:    validStateFilter == phoneutils.ValidState,
-				NotValidOnly: validStateFilter == phoneutils.NotValidState,
-				PhoneNumber:  phoneFilter,
-			},
-		})
+			PageToken: pageToken,
+			Filters:   indexFilters(c),
+			OrderBy:   orderBy,
+		}
+		listRes, err := appV1.ListPhoneRecords(c.Request.Context(), listReq)
+		if errors.Is(err, phonebook_v1.ErrPageTokenMismatch) {
+			// Filters were changed before paging, start again from the first page
+			listReq.PageToken = ""
+			listRes, err = appV1.ListPhoneRecords(c.Request.Context(), listReq)
+		}
 		if err != nil {
-			c.AbortWithStatus(http.StatusInternalServerError)
+			c.AbortWithStatus(httpStatus(status.Code(err)))
 			return
 		}
 
-		// Get all countries
-		countries := make([]*models.Country, 0, 10)
-		err = db.Model(&models.Country{}).Find(&countries).Error
+		// Get all countries, new records can only use enabled ones
+		countriesRes, err := countriesV1.ListCountries(c.Request.Context(), &phonebook_v1.ListCountriesRequest{})
 		if err != nil {
-			c.AbortWithStatus(http.StatusInternalServerError)
+			c.AbortWithStatus(httpStatus(status.Code(err)))
 			return
 		}
+		enabledCountries := make([]*phonebook_v1.Country, 0, len(countriesRes.Countries))
+		for _, country := range countriesRes.Countries {
+			if !country.Disabled {
+				enabledCountries = append(enabledCountries, country)
+			}
+		}
 
-		// Update some values for pagination
-		if pagination.SessionExist(sessionId) {
-			pageInfo = pagination.SetNextPageInfo(sessionId, listRes.NextPageToken, pageInfo.PageToken)
-		} else {
-			sessionId = pagination.SetNewSession(listRes.CollectionCount, listRes.NextPageToken)
+		// Record being edited
+		if editId != "" {
+			editPhone, err = appV1.GetPhoneRecord(c.Request.Context(), &phonebook_v1.GetPhoneRecordRequest{
+				RecordId: editId,
+			})
+			if err != nil {
+				c.AbortWithStatus(httpStatus(status.Code(err)))
+				return
+			}
 		}
 
 		// Render HTML
 		c.HTML(http.StatusOK, "index.html", gin.H{
 			"phones":            listRes.PhoneRecords,
-			"countries":         countries,
+			"countries":         countriesRes.Countries,
+			"enabledCountries":  enabledCountries,
 			"pageSize":          pageSize,
 			"validStateFilter":  validStateFilter,
 			"countryCodeFilter": countryCodeFilter,
 			"phoneFilter":       phoneFilter,
+			"phoneMatchFilter":  phoneMatchFilter,
+			"numberMatches": []string{
+				phonebook_v1.NumberMatchExact,
+				phonebook_v1.NumberMatchPrefix,
+				phonebook_v1.NumberMatchSuffix,
+				phonebook_v1.NumberMatchContains,
+			},
+			"numberTypeFilter":  numberTypeFilter,
+			"numberTypes":       phoneutils.NumberTypes,
+			"operatorFilter":    operatorFilter,
+			"operators":         phoneutils.Operators(),
+			"custIdFilter":      custIdFilter,
+			"createdFromFilter": createdFromFilter,
+			"createdToFilter":   createdToFilter,
 			"nextPageToken":     listRes.NextPageToken,
-			"collectionCount":   pageInfo.CollectionCount,
-			"pageNumber":        pageInfo.PageNumber,
-			"prevPageToken":     pageInfo.PageToken,
-			"sessionId":         sessionId,
+			"collectionCount":   listRes.CollectionCount,
+			"pageNumber":        listRes.PageNumber,
+			"prevPageToken":     listRes.PrevPageToken,
+			"editPhone":         editPhone,
+			"orderBy":           orderBy,
+			"sortHeaders":       sortHeaders(c, orderBy),
 		})
 	})
 
 	handleError(router.Run(*port))
 }
 
+// indexFilters reads the filters of the index page form
+func indexFilters(c *gin.Context) *phonebook_v1.PhoneRecordsFilters {
+	validStateFilter := c.Query("validStateFilter")
+	return &phonebook_v1.PhoneRecordsFilters{
+		CountryCode:      c.Query("countryCodeFilter"),
+		ValidOnly:        validStateFilter == phoneutils.ValidState,
+		NotValidOnly:     validStateFilter == phoneutils.NotValidState,
+		PhoneNumber:      c.Query("phoneFilter"),
+		NumberType:       c.Query("numberTypeFilter"),
+		Operator:         c.Query("operatorFilter"),
+		PhoneNumberMatch: c.Query("phoneMatchFilter"),
+		CustIds:          queryList(c, "custIdFilter"),
+		CreatedAfter:     dayStart(c.Query("createdFromFilter"), 0),
+		CreatedBefore:    dayStart(c.Query("createdToFilter"), 1),
+	}
+}
+
+// dayStart turns a date from the form into the RFC3339 start of the day, days later.
+// Values that are not dates are passed on for the service to reject.
+func dayStart(date string, days int) string {
+	t, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return date
+	}
+	return t.AddDate(0, 0, days).Format(time.RFC3339)
+}
+
+// sortableColumns are the index table columns that can be ordered by
+var sortableColumns = []string{"country_name", "country_code", "number", "number_e164", "create_date"}
+
+type sortHeader struct {
+	Link  string
+	Arrow string
+}
+
+// sortHeaders links each sortable column to the index ordered by it, clicking the current column flips the direction
+func sortHeaders(c *gin.Context, orderBy string) map[string]*sortHeader {
+	current := strings.Fields(strings.ToLower(orderBy))
+	if len(current) == 1 {
+		current = append(current, "asc")
+	}
+
+	headers := make(map[string]*sortHeader, len(sortableColumns))
+	for _, column := range sortableColumns {
+		header := &sortHeader{}
+		dir := "asc"
+		if len(current) == 2 && current[0] == column {
+			if current[1] == "asc" {
+				header.Arrow, dir = "▲", "desc"
+			} else {
+				header.Arrow = "▼"
+			}
+		}
+
+		// Keep the filters, a new order starts from the first page
+		query := c.Request.URL.Query()
+		query.Del("pageToken")
+		query.Del("editId")
+		query.Set("orderBy", column+" "+dir)
+		header.Link = "/?" + query.Encode()
+
+		headers[column] = header
+	}
+
+	return headers
+}
+
 func handleError(err error) {
 	if err != nil {
 		panic(err)
 	}
 }
 
-var countries = []*models.Country{
-	{
-		CountryCode: 237,
-		CountryName: "Cameroon",
-	},
-	{
-		CountryCode: 251,
-		CountryName: "Ethiopia",
-	},
-	{
-		CountryCode: +212,
-		CountryName: "Morocco",
-	},
-	{
-		CountryCode: 258,
-		CountryName: "Mozambique",
-	},
-	{
-		CountryCode: +256,
-		CountryName: "Uganda",
-	},
+// openDB connects to the configured database
+func openDB() (*gorm.DB, error) {
+	return database.Open(*dbDriver, *dsn, &gorm.Config{})
+}
+
+// loadRules replaces the country rules used for validation with rules from the configured source. Countries of the
+// countries table the source has no rules for are added, records of countries added at runtime are validated too.
+func loadRules(db *gorm.DB) error {
+	// Disabled countries and those added for old records without rules are not validated against.
+	// The oldest of rows whose names only differ in case is used, like when records pick their country.
+	countries := make([]*models.Country, 0, 10)
+	err := db.Model(&models.Country{}).Where("disabled = ? AND patterns <> ?", false, "").Order("id").Find(&countries).Error
+	if err != nil {
+		return err
+	}
+	countryRules := make([]*phoneutils.CountryRule, 0, len(countries))
+	for _, country := range countries {
+		rule, err := country.Rule()
+		if err != nil {
+			return err
+		}
+		countryRules = append(countryRules, rule)
+	}
+
+	var rs *phoneutils.RuleSet
+	switch {
+	case *rulesFromDB:
+		if len(countryRules) == 0 {
+			return errors.New("no country rules found in countries table")
+		}
+		rs = &phoneutils.RuleSet{}
+	case *rules != "":
+		rs, err = phoneutils.LoadRulesFile(*rules)
+	default:
+		rs, err = phoneutils.DefaultRules()
+	}
+	if err != nil {
+		return err
+	}
+
+	return phoneutils.DefaultRegistry.Load(rs.Extend(countryRules...))
 }
 
 func addCounties(db *gorm.DB) error {
+	rules := phoneutils.DefaultRegistry.Rules()
+	countries := make([]*models.Country, 0, len(rules))
+	for _, rule := range rules {
+		countries = append(countries, models.CountryFromRule(rule))
+	}
 	return db.CreateInBatches(countries, 10).Error
 }
 
-func randomCountry() *models.Country {
+func randomCountry(countries []*models.Country) *models.Country {
 	return countries[rand.Intn(len(countries))]
 }
 
-var states = []bool{true, true, false}
-
-func randomState() bool {
-	return states[rand.Intn(len(states))]
-}
-
 func addRandomPhones(db *gorm.DB) error {
-	var err error
+	countries := make([]*models.Country, 0, 10)
+	err := db.Find(&countries).Error
+	if err != nil {
+		return err
+	}
 	for i := 0; i < 100; i++ {
-		country := randomCountry()
+		country := randomCountry(countries)
+		number := fmt.Sprint(randomdata.Number(100000000, 999999999))
+		res := phoneutils.Validate(number, country.CountryName)
+		keys := phoneutils.NumberSearchKeys(number, country.CountryName)
 		err = db.Create(&models.Phone{
-			Country: models.Country{
-				CountryCode: country.CountryCode,
-				CountryName: country.CountryName,
-			},
-			PhoneValid: randomState(),
-			Number:     fmt.Sprint(randomdata.Number(100000000, 999999999)),
+			CountryID:         country.ID,
+			PhoneValid:        res.Valid,
+			Number:            number,
+			NumberE164:        phoneutils.NormalizeE164(number, country.CountryName),
+			NationalNumber:    keys.National,
+			NumberReversed:    keys.Reversed,
+			NumberType:        res.NumberType,
+			Operator:          res.Operator,
+			ValidationReasons: strings.Join(res.Reasons, ","),
+			RuleVersion:       res.RuleVersion,
 		}).Error
 		if err != nil {
 			return err
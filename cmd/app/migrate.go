@@ -0,0 +1,100 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gidyon/jumia-exercise/internal/database"
+	"github.com/gidyon/jumia-exercise/internal/migrations"
+	"gorm.io/gorm"
+)
+
+// runMigrate applies, undoes or lists the versioned schema migrations of the database used by the server
+func runMigrate(args []string) error {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: app migrate [flags] <up | down | status>")
+		fs.PrintDefaults()
+	}
+
+	var (
+		to    = fs.Uint("to", 0, "Version up applies migrations to, the latest version when 0")
+		steps = fs.Int("steps", 1, "Number of migrations down undoes, newest first")
+	)
+	fs.StringVar(dbDriver, "dbDriver", database.DriverSQLite, "Database driver, one of sqlite, postgres or mysql")
+	fs.StringVar(dsn, "dsn", "phones.db", "Database connection string, a file path for sqlite")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("missing migrate command")
+	}
+
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+
+	switch fs.Arg(0) {
+	case "up":
+		applied, err := migrations.Up(db, *to)
+		for _, m := range applied {
+			fmt.Printf("applied %d %s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Println("no migration to apply")
+		}
+	case "down":
+		if *steps < 1 {
+			return fmt.Errorf("steps must be at least 1, got %d", *steps)
+		}
+		undone, err := migrations.Down(db, *steps)
+		for _, m := range undone {
+			fmt.Printf("undone %d %s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(undone) == 0 {
+			fmt.Println("no migration to undo")
+		}
+	case "status":
+		statuses, err := migrations.List(db)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.Applied {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+		return w.Flush()
+	default:
+		fs.Usage()
+		return fmt.Errorf("unknown migrate command %q", fs.Arg(0))
+	}
+
+	return nil
+}
+
+// checkSchema makes sure the database is fully migrated before it is used
+func checkSchema(db *gorm.DB) error {
+	err := migrations.Check(db)
+	if errors.Is(err, migrations.ErrNotMigrated) {
+		return fmt.Errorf("%w, apply the migrations with: app migrate up", err)
+	}
+	return err
+}
@@ -1,18 +1,24 @@
 module github.com/gidyon/jumia-exercise
 
-go 1.15
+go 1.16
 
 require (
 	github.com/Pallinder/go-randomdata v1.2.0
 	github.com/gidyon/micro v1.12.0
 	github.com/gin-gonic/gin v1.7.7
+	github.com/go-sql-driver/mysql v1.6.0
+	github.com/golang/protobuf v1.4.3
+	github.com/jackc/pgconn v1.10.1
+	github.com/mattn/go-sqlite3 v1.14.9
 	github.com/onsi/ginkgo v1.14.2
 	github.com/onsi/gomega v1.10.4
 	github.com/rs/zerolog v1.20.0
 	golang.org/x/net v0.0.0-20210405180319-a5a99cb37ef4 // indirect
-	golang.org/x/sys v0.0.0-20210510120138-977fb7262007 // indirect
 	google.golang.org/grpc v1.44.0
-	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b // indirect
+	google.golang.org/protobuf v1.25.0
+	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b
+	gorm.io/driver/mysql v1.2.3
+	gorm.io/driver/postgres v1.2.3
 	gorm.io/driver/sqlite v1.2.6
 	gorm.io/gorm v1.22.5
 )
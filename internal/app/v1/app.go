@@ -3,133 +3,151 @@ package app
 
 import (
 	"context"
-	"encoding/base64"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/gidyon/jumia-exercise/internal/migrations"
 	"github.com/gidyon/jumia-exercise/internal/models"
+	"github.com/gidyon/jumia-exercise/internal/repository"
 	phonebook_v1 "github.com/gidyon/jumia-exercise/pkg/api/phonebook/v1"
 	"github.com/gidyon/jumia-exercise/pkg/utils/phoneutils"
 	"github.com/gidyon/micro/utils/errs"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
 )
 
 type Options struct {
-	SqlDB  *gorm.DB
-	Logger *zerolog.Logger
+	SqlDB *gorm.DB
+	// Repository stores the records, a repository on the tables of SqlDB is used when it is nil
+	Repository repository.PhoneRepository
+	Logger     *zerolog.Logger
+	// TrashRetention is how long deleted records are kept before being purged, zero keeps them forever
+	TrashRetention time.Duration
+	// PageTokenSecret signs page tokens, replicas must share it. A random secret is used when empty
+	PageTokenSecret []byte
+	// ReloadRules is called after countries change so validation uses their rules, it is optional
+	ReloadRules func() error
 }
 
 func NewPhoneBookService(ctx context.Context, opt *Options) (phonebook_v1.PhoneBookService, error) {
+	pb, err := newPhoneBookAPIServer(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.TrashRetention > 0 {
+		go pb.purgeWorker(ctx)
+	}
+
+	return pb, nil
+}
+
+type phoneBookAPIServer struct {
+	*Options
+	repo       repository.PhoneRepository
+	pageTokens *phoneutils.PageTokenCodec
+}
+
+func newPhoneBookAPIServer(opt *Options) (*phoneBookAPIServer, error) {
 	switch {
 	case opt == nil:
 		return nil, errors.New("missing opts")
-	case opt.SqlDB == nil:
-		return nil, errors.New("missing sql db")
+	case opt.SqlDB == nil && opt.Repository == nil:
+		return nil, errors.New("missing sql db or repository")
 	case opt.Logger == nil:
 		return nil, errors.New("missing logger")
 	}
-	pb := &phoneBookAPIServer{
-		Options: opt,
-	}
 
-	// Auto migrations only if tables don't exist
-	if !opt.SqlDB.Migrator().HasTable(&models.Phone{}) {
-		err := opt.SqlDB.AutoMigrate(&models.Phone{})
+	repo := opt.Repository
+	if repo == nil {
+		// Tables are created and changed by versioned migrations, not by the services
+		err := migrations.Check(opt.SqlDB)
 		if err != nil {
-			return nil, fmt.Errorf("failed to automigrate phones table: %w", err)
+			return nil, err
 		}
+		repo = repository.NewGorm(opt.SqlDB)
 	}
-	if !opt.SqlDB.Migrator().HasTable(&models.Country{}) {
-		err := opt.SqlDB.AutoMigrate(&models.Country{})
-		if err != nil {
-			return nil, fmt.Errorf("failed to automigrate countries table: %w", err)
-		}
+
+	pageTokens, err := phoneutils.NewPageTokenCodec(opt.PageTokenSecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create page token codec: %w", err)
 	}
-	return pb, nil
-}
 
-type phoneBookAPIServer struct {
-	*Options
+	return &phoneBookAPIServer{
+		Options:    opt,
+		repo:       repo,
+		pageTokens: pageTokens,
+	}, nil
 }
 
 func (pb *phoneBookAPIServer) CreatePhoneRecord(
 	ctx context.Context, req *phonebook_v1.PhoneRecord,
 ) (*phonebook_v1.PhoneRecord, error) {
-	// Validate fields
-	switch {
-	case req == nil:
-		return nil, errors.New("missing phonebook")
-	case req.CountryName == "":
-		return nil, errors.New("missing country")
-	case req.Number == "":
-		return nil, errors.New("missing phone number")
-	}
-
-	// Validate phone
-	phoneutils.ValidatePhone(req)
-
-	db := &models.Phone{
-		ID: 0,
-		Country: models.Country{
-			CountryCode: req.CountryCode,
-			CountryName: req.CountryName,
-		},
-		Number:     req.Number,
-		CustId:     req.CustId,
-		PhoneValid: req.PhoneValid,
+	db, err := pb.newPhoneModel(ctx, req)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create phone
-	err := pb.SqlDB.Create(db).Error
-	if err != nil {
+	err = pb.repo.CreatePhone(ctx, db)
+	switch {
+	case err == nil:
+	case errors.Is(err, repository.ErrMissingReference):
+		return nil, errs.WrapMessagef(codes.InvalidArgument, "customer %q does not exist", req.CustId)
+	default:
 		pb.Logger.Error().Str("method", "CreatePhoneRecord").Str("error", err.Error()).Msg("failed to create phone record")
-		return nil, errors.New("creating phone record failed")
+		return nil, errs.WrapMessage(codes.Internal, "creating phone record failed")
 	}
 
-	return &phonebook_v1.PhoneRecord{
-		Id:          fmt.Sprint(db.ID),
-		CustId:      db.CustId,
-		CountryName: db.Country.CountryName,
-		CountryCode: db.Country.CountryCode,
-		Number:      db.Number,
-		PhoneValid:  db.PhoneValid,
-		CreateDate:  db.CreateDate.UTC().Format(time.RFC3339),
-	}, nil
+	return getPhoneRecordPB(db), nil
 }
 
 func (pb *phoneBookAPIServer) GetPhoneRecord(
 	ctx context.Context, req *phonebook_v1.GetPhoneRecordRequest,
 ) (*phonebook_v1.PhoneRecord, error) {
 	if req.RecordId == "" {
-		return nil, errors.New("missing phone record id")
+		return nil, errs.WrapMessage(codes.InvalidArgument, "missing phone record id")
+	}
+
+	db, err := pb.getPhone(ctx, "GetPhoneRecord", req.RecordId)
+	if err != nil {
+		return nil, err
 	}
 
-	db := &models.Phone{}
+	return getPhoneRecordPB(db), nil
+}
+
+// getPhone gets a phone record that is not in the trash with its country
+func (pb *phoneBookAPIServer) getPhone(ctx context.Context, method, recordId string) (*models.Phone, error) {
+	id, ok := parseRecordId(recordId)
+	if !ok {
+		return nil, errs.WrapMessage(codes.NotFound, "record not found")
+	}
 
-	// Get from db
-	err := pb.SqlDB.First(db, "id = ?", req.RecordId).Error
+	db, err := pb.repo.GetPhone(ctx, id)
 	switch {
 	case err == nil:
-	case errors.Is(err, gorm.ErrRecordNotFound):
-		return nil, errors.New("record not found")
+		return db, nil
+	case errors.Is(err, repository.ErrNotFound):
+		return nil, errs.WrapMessage(codes.NotFound, "record not found")
 	default:
-		pb.Logger.Error().Str("method", "GetPhoneRecord").Str("error", err.Error()).Msg("failed to get phone record")
-		return nil, errors.New("getting phone record failed")
+		pb.Logger.Error().Str("method", method).Str("error", err.Error()).Msg("failed to get phone record")
+		return nil, errs.WrapMessage(codes.Internal, "getting phone record failed")
 	}
+}
 
-	return &phonebook_v1.PhoneRecord{
-		Id:          fmt.Sprint(db.ID),
-		CustId:      db.CustId,
-		CountryName: db.Country.CountryName,
-		CountryCode: db.Country.CountryCode,
-		Number:      db.Number,
-		PhoneValid:  db.PhoneValid,
-		CreateDate:  db.CreateDate.UTC().Format(time.RFC3339),
-	}, nil
+// parseRecordId reads the id of a phone record, ids that are not numbers belong to no record
+func parseRecordId(recordId string) (uint, bool) {
+	id, err := strconv.ParseUint(recordId, 10, 64)
+	return uint(id), err == nil
 }
 
 const defaultPageSize = 50
@@ -137,12 +155,23 @@ const defaultPageSize = 50
 func (pb *phoneBookAPIServer) ListPhoneRecords(
 	ctx context.Context, req *phonebook_v1.ListPhoneRecordsRequest,
 ) (*phonebook_v1.ListPhoneRecordsResponse, error) {
-	var (
-		pageSize  = req.PageSize
-		pageToken = req.PageToken
+	return pb.listPhoneRecords(ctx, req, listingPhones, repository.NotDeleted)
+}
+
+// Listings served by listPhoneRecords, page tokens only work in the listing that created them
+const (
+	listingPhones        = "phones"
+	listingDeletedPhones = "deleted_phones"
+)
 
-		ID  uint
-		err error
+// listPhoneRecords lists a page of the phones in the trash scope after applying the request filters
+func (pb *phoneBookAPIServer) listPhoneRecords(
+	ctx context.Context, req *phonebook_v1.ListPhoneRecordsRequest, listing string, trash repository.Trash,
+) (*phonebook_v1.ListPhoneRecordsResponse, error) {
+	var (
+		pageSize = req.PageSize
+		token    = &phoneutils.PageToken{Direction: phoneutils.PageNext, PageNumber: 1}
+		err      error
 	)
 
 	switch {
@@ -152,101 +181,368 @@ func (pb *phoneBookAPIServer) ListPhoneRecords(
 		pageSize = defaultPageSize
 	}
 
-	if pageToken != "" {
-		bs, err := base64.StdEncoding.DecodeString(pageToken)
-		if err != nil {
-			return nil, errs.WrapErrorWithCodeAndMsg(codes.InvalidArgument, err, "failed to parse page token")
-		}
-		v, err := strconv.ParseUint(string(bs), 10, 64)
-		if err != nil {
-			return nil, errs.WrapErrorWithCodeAndMsg(codes.InvalidArgument, err, "incorrect page token")
-		}
-		ID = uint(v)
+	order, err := parsePhoneOrder(req.OrderBy)
+	if err != nil {
+		return nil, err
 	}
 
-	// Default db settings
-	db := pb.SqlDB.Unscoped().Limit(int(pageSize + 1)).Order("id DESC").Model(&models.Phone{})
-	if ID != 0 {
-		db = db.Where("id<?", ID)
-	}
+	queryHash := pageQueryHash(listing, req.Filters, pageSize, order)
 
-	// Apply filters
-	if req.Filters != nil {
-		if req.Filters.PhoneNumber != "" {
-			db = db.Where("number  = ?", req.Filters.PhoneNumber)
-		}
-		if req.Filters.CountryCode != "" {
-			db = db.Where("country_code  = ?", req.Filters.CountryCode)
+	if req.PageToken != "" {
+		token, err = pb.pageTokens.Decode(req.PageToken)
+		if err != nil {
+			return nil, errs.WrapErrorWithCodeAndMsg(codes.InvalidArgument, err, "incorrect page token")
 		}
-		if req.Filters.ValidOnly && req.Filters.NotValidOnly {
-		} else if req.Filters.ValidOnly {
-			db = db.Where("phone_valid  = ?", true)
-		} else if req.Filters.NotValidOnly {
-			db = db.Where("phone_valid  = ?", false)
+		// A token only makes sense for the query it was created for
+		if token.FilterHash != queryHash {
+			return nil, &statusError{code: codes.InvalidArgument, err: phonebook_v1.ErrPageTokenMismatch}
 		}
 	}
 
-	var collectionCount int64
+	// Apply filters
+	filters, err := phoneFilters(req.Filters)
+	if err != nil {
+		return nil, err
+	}
+	query := &repository.PhoneQuery{Trash: trash, Filters: *filters}
 
-	if pageToken == "" {
-		err = db.Count(&collectionCount).Error
+	// Counted once, later pages carry the count in their token
+	collectionCount := int64(token.CollectionCount)
+	if req.PageToken == "" {
+		collectionCount, err = pb.repo.CountPhones(ctx, query)
 		if err != nil {
 			return nil, errs.SQLQueryFailed(err, "count")
 		}
 	}
 
-	dbs := make([]*models.Phone, 0, pageSize+1)
-	err = db.Find(&dbs).Error
+	// Previous pages are read in reverse order and flipped back into listing order
+	query.Limit = int(pageSize + 1)
+	query.Order = order.query()
 	switch {
-	case err == nil:
-	default:
+	case token.Direction == phoneutils.PagePrev:
+		query.Order = order.reversed().query()
+		query.After, err = order.after(token)
+		if err != nil {
+			return nil, err
+		}
+	case req.PageToken != "":
+		query.After, err = order.after(token)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dbs, err := pb.repo.ListPhones(ctx, query)
+	if err != nil {
 		return nil, errs.SQLQueryFailed(err, "LIST")
 	}
 
-	pbs := make([]*phonebook_v1.PhoneRecord, 0, len(dbs))
+	more := len(dbs) > int(pageSize)
+	if more {
+		dbs = dbs[:pageSize]
+	}
 
-	for i, db := range dbs {
-		if i == int(pageSize) {
-			break
+	var (
+		pageNumber = token.PageNumber
+		hasNext    bool
+		hasPrev    bool
+	)
+
+	if token.Direction == phoneutils.PagePrev {
+		for i, j := 0, len(dbs)-1; i < j; i, j = i+1, j-1 {
+			dbs[i], dbs[j] = dbs[j], dbs[i]
 		}
-		pbs = append(pbs, &phonebook_v1.PhoneRecord{
-			Id:          fmt.Sprint(db.ID),
-			CustId:      db.CustId,
-			CountryName: db.Country.CountryName,
-			CountryCode: db.Country.CountryCode,
-			Number:      db.Number,
-			PhoneValid:  db.PhoneValid,
-			CreateDate:  db.CreateDate.UTC().Format(time.RFC3339),
-		})
-		ID = db.ID
+		hasNext, hasPrev = true, more
+		if !more {
+			// Nothing comes before, even if records were added since the count
+			pageNumber = 1
+		}
+	} else {
+		hasNext, hasPrev = more, req.PageToken != ""
+	}
+
+	// An empty page has no record to page from, listing starts again without a token
+	if len(dbs) == 0 {
+		hasNext, hasPrev = false, false
 	}
 
-	var token string
-	if len(dbs) > int(pageSize) {
-		// Next page token
-		token = base64.StdEncoding.EncodeToString([]byte(fmt.Sprint(ID)))
+	pbs := make([]*phonebook_v1.PhoneRecord, 0, len(dbs))
+	for _, db := range dbs {
+		pbs = append(pbs, getPhoneRecordPB(db))
 	}
 
-	return &phonebook_v1.ListPhoneRecordsResponse{
-		NextPageToken:   token,
+	res := &phonebook_v1.ListPhoneRecordsResponse{
 		PhoneRecords:    pbs,
 		CollectionCount: int32(collectionCount),
-	}, nil
+		PageNumber:      pageNumber,
+	}
+
+	if hasNext {
+		last := dbs[len(dbs)-1]
+		res.NextPageToken = pb.pageTokens.Encode(&phoneutils.PageToken{
+			Direction:       phoneutils.PageNext,
+			Cursor:          last.ID,
+			SortValue:       order.cursor(last),
+			PageNumber:      pageNumber + 1,
+			CollectionCount: int32(collectionCount),
+			FilterHash:      queryHash,
+		})
+	}
+	if hasPrev && pageNumber > 1 {
+		first := dbs[0]
+		res.PrevPageToken = pb.pageTokens.Encode(&phoneutils.PageToken{
+			Direction:       phoneutils.PagePrev,
+			Cursor:          first.ID,
+			SortValue:       order.cursor(first),
+			PageNumber:      pageNumber - 1,
+			CollectionCount: int32(collectionCount),
+			FilterHash:      queryHash,
+		})
+	}
+
+	return res, nil
+}
+
+// filterCreateDate narrows filters to records created in the range of the request filters
+func filterCreateDate(filters *repository.PhoneFilters, req *phonebook_v1.PhoneRecordsFilters) error {
+	if req.CreatedAfter != "" {
+		t, err := time.Parse(time.RFC3339, req.CreatedAfter)
+		if err != nil {
+			return errs.WrapMessagef(codes.InvalidArgument, "created_after %q is not an RFC3339 time", req.CreatedAfter)
+		}
+		// Compared in the zone create dates are stored in
+		filters.CreatedAfter = t.Local()
+	}
+	if req.CreatedBefore != "" {
+		t, err := time.Parse(time.RFC3339, req.CreatedBefore)
+		if err != nil {
+			return errs.WrapMessagef(codes.InvalidArgument, "created_before %q is not an RFC3339 time", req.CreatedBefore)
+		}
+		filters.CreatedBefore = t.Local()
+	}
+
+	after, before := filters.CreatedAfter, filters.CreatedBefore
+	if !after.IsZero() && !before.IsZero() && !after.Before(before) {
+		return errs.WrapMessage(codes.InvalidArgument, "created_after must be before created_before")
+	}
+
+	return nil
+}
+
+// statusError is a status error with the given code that also matches err with errors.Is
+type statusError struct {
+	code codes.Code
+	err  error
+}
+
+func (e *statusError) Error() string {
+	return e.GRPCStatus().Err().Error()
+}
+
+func (e *statusError) GRPCStatus() *status.Status {
+	return status.New(e.code, e.err.Error())
+}
+
+func (e *statusError) Unwrap() error {
+	return e.err
+}
+
+// pageQueryHash identifies the listing, filters, page size and order a page token was created for
+func pageQueryHash(listing string, filters *phonebook_v1.PhoneRecordsFilters, pageSize int32, order *phoneOrder) string {
+	if filters == nil {
+		filters = &phonebook_v1.PhoneRecordsFilters{}
+	}
+	bs, _ := json.Marshal(struct {
+		Listing  string
+		Filters  *phonebook_v1.PhoneRecordsFilters
+		PageSize int32
+		OrderBy  string
+	}{listing, filters, pageSize, order.String()})
+	sum := sha256.Sum256(bs)
+	return hex.EncodeToString(sum[:8])
+}
+
+// phoneFilters converts the request filters to the repository filters selecting the same records
+func phoneFilters(req *phonebook_v1.PhoneRecordsFilters) (*repository.PhoneFilters, error) {
+	filters := &repository.PhoneFilters{}
+	if req == nil {
+		return filters, nil
+	}
+
+	err := filterPhoneNumber(filters, req)
+	if err != nil {
+		return nil, err
+	}
+	filters.CountryCode = req.CountryCode
+	filters.NumberType = req.NumberType
+	filters.Operator = req.Operator
+	for _, custId := range req.CustIds {
+		id, err := strconv.ParseUint(custId, 10, 64)
+		if err != nil {
+			return nil, errs.WrapMessagef(codes.InvalidArgument, "incorrect customer id %q", custId)
+		}
+		filters.CustomerIDs = append(filters.CustomerIDs, uint(id))
+	}
+
+	err = filterCreateDate(filters, req)
+	if err != nil {
+		return nil, err
+	}
+	if req.ValidOnly != req.NotValidOnly {
+		valid := req.ValidOnly
+		filters.Valid = &valid
+	}
+
+	return filters, nil
 }
 
 func (pb *phoneBookAPIServer) DeletePhoneRecord(
 	ctx context.Context, req *phonebook_v1.DeletePhoneRecordRequest,
 ) error {
 	if req.RecordId == "" {
-		return errors.New("missing phone record id")
+		return errs.WrapMessage(codes.InvalidArgument, "missing phone record id")
+	}
+
+	// Ids that are not numbers belong to no record, there is nothing to delete
+	id, ok := parseRecordId(req.RecordId)
+	if !ok {
+		return nil
 	}
 
-	// Delete from db
-	err := pb.SqlDB.Delete(&models.Phone{}, "id = ?", req.RecordId).Error
+	// Soft delete, the record stays in the trash until restored or purged
+	_, err := pb.repo.DeletePhones(ctx, []uint{id}, req.DeletedBy, false)
 	if err != nil {
 		pb.Logger.Error().Str("method", "DeletePhoneRecord").Str("error", err.Error()).Msg("failed to delete phone record")
-		return errors.New("deleting phone record failed")
+		return errs.WrapMessage(codes.Internal, "deleting phone record failed")
 	}
 
 	return nil
 }
+
+// newPhoneModel checks the fields of a new phone record and returns its validated model
+func (pb *phoneBookAPIServer) newPhoneModel(ctx context.Context, req *phonebook_v1.PhoneRecord) (*models.Phone, error) {
+	// Validate fields
+	switch {
+	case req == nil:
+		return nil, errs.WrapMessage(codes.InvalidArgument, "missing phonebook")
+	case req.Number == "":
+		return nil, errs.WrapMessage(codes.InvalidArgument, "missing phone number")
+	}
+
+	// Infer country from the dial code in the number
+	country, err := pb.resolveCountry(ctx, req.CountryName, req.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	customerID, err := pb.checkCustomer(ctx, req.CustId)
+	if err != nil {
+		return nil, err
+	}
+
+	db := &models.Phone{
+		ID:         0,
+		CountryID:  country.ID,
+		Country:    country,
+		CustomerID: customerID,
+		Number:     req.Number,
+	}
+
+	// Validate phone
+	validatePhoneModel(db)
+
+	return db, nil
+}
+
+// resolveCountry finds the country of a phone number, a missing country name is detected from the dial code in the number
+func (pb *phoneBookAPIServer) resolveCountry(ctx context.Context, countryName, number string) (*models.Country, error) {
+	if countryName == "" {
+		country, err := pb.detectCountry(ctx, number)
+		switch {
+		case err == nil:
+			return country, nil
+		case errors.Is(err, errUnknownCountry), errors.Is(err, errAmbiguousCountry):
+			return nil, errs.WrapErrorWithCode(codes.InvalidArgument, err)
+		default:
+			return nil, err
+		}
+	}
+
+	country, err := countryByName(ctx, pb.repo, countryName)
+	switch {
+	case err == nil && country.Disabled:
+		return nil, errs.WrapMessagef(codes.InvalidArgument, "country %q is disabled", country.CountryName)
+	case err == nil:
+		return country, nil
+	case errors.Is(err, repository.ErrNotFound):
+		return nil, errs.WrapMessagef(codes.InvalidArgument, "country %q is not supported", countryName)
+	default:
+		pb.Logger.Error().Str("method", "resolveCountry").Str("error", err.Error()).Msg("failed to get country")
+		return nil, errs.WrapMessage(codes.Internal, "getting country failed")
+	}
+}
+
+// validatePhoneModel validates the phone number and stores the outcome on the model
+func validatePhoneModel(db *models.Phone) {
+	country := phoneCountry(db)
+	pr := &phonebook_v1.PhoneRecord{
+		CountryName: country.CountryName,
+		CountryCode: country.CountryCode,
+		Number:      db.Number,
+	}
+
+	phoneutils.ValidatePhone(pr)
+
+	db.NumberE164 = phoneutils.NormalizeE164(db.Number, country.CountryName)
+	setSearchKeys(db)
+	db.NumberType = pr.NumberType
+	db.Operator = pr.Operator
+	db.PhoneValid = pr.PhoneValid
+	db.ValidationReasons = strings.Join(pr.Validation.Reasons, ",")
+	db.RuleVersion = pr.Validation.RuleVersion
+}
+
+func getPhoneRecordPB(db *models.Phone) *phonebook_v1.PhoneRecord {
+	country := phoneCountry(db)
+	pb := &phonebook_v1.PhoneRecord{
+		Id:          fmt.Sprint(db.ID),
+		CountryName: country.CountryName,
+		CountryCode: country.CountryCode,
+		Number:      db.Number,
+		NumberE164:  db.NumberE164,
+		NumberType:  db.NumberType,
+		Operator:    db.Operator,
+		PhoneValid:  db.PhoneValid,
+		CreateDate:  db.CreateDate.UTC().Format(time.RFC3339),
+	}
+
+	if db.CustomerID != nil {
+		pb.CustId = fmt.Sprint(*db.CustomerID)
+	}
+
+	if db.UpdateDate != nil {
+		pb.UpdateDate = db.UpdateDate.UTC().Format(time.RFC3339)
+	}
+
+	if db.DeletedAt.Valid {
+		pb.DeleteDate = db.DeletedAt.Time.UTC().Format(time.RFC3339)
+		pb.DeletedBy = db.DeletedBy
+	}
+
+	// Records created before validation results were stored have no rule version
+	if db.RuleVersion != "" {
+		pb.Validation = &phonebook_v1.ValidationResult{
+			Valid:       db.PhoneValid,
+			CountryName: country.CountryName,
+			CountryCode: country.CountryCode,
+			NumberType:  db.NumberType,
+			Operator:    db.Operator,
+			RuleVersion: db.RuleVersion,
+		}
+		if db.ValidationReasons != "" {
+			pb.Validation.Reasons = strings.Split(db.ValidationReasons, ",")
+		}
+	}
+
+	return pb
+}
@@ -0,0 +1,187 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gidyon/jumia-exercise/internal/models"
+	"github.com/gidyon/jumia-exercise/internal/repository"
+	phonebook_v1 "github.com/gidyon/jumia-exercise/pkg/api/phonebook/v1"
+	"github.com/gidyon/micro/utils/errs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxBatchItems is the most items a single batch request may carry
+const maxBatchItems = 5000
+
+var errBatchAborted = errs.WrapMessage(codes.Aborted, "not applied because another item in the batch failed")
+
+func (pb *phoneBookAPIServer) BatchCreatePhoneRecords(
+	ctx context.Context, req *phonebook_v1.BatchCreatePhoneRecordsRequest,
+) (*phonebook_v1.BatchPhoneRecordsResponse, error) {
+	// Validate request
+	switch {
+	case req == nil:
+		return nil, errs.WrapMessage(codes.InvalidArgument, "missing batch request")
+	case len(req.PhoneRecords) == 0:
+		return nil, errs.WrapMessage(codes.InvalidArgument, "missing phone records")
+	case len(req.PhoneRecords) > maxBatchItems:
+		return nil, errs.WrapMessagef(codes.InvalidArgument, "too many phone records, at most %d are allowed", maxBatchItems)
+	}
+
+	results := make([]*phonebook_v1.BatchItemResult, len(req.PhoneRecords))
+	dbs := make([]*models.Phone, 0, len(req.PhoneRecords))
+	indexes := make([]int, 0, len(req.PhoneRecords))
+
+	// Validate each item
+	for i, pr := range req.PhoneRecords {
+		results[i] = &phonebook_v1.BatchItemResult{Index: int32(i)}
+		db, err := pb.newPhoneModel(ctx, pr)
+		if err != nil {
+			setBatchItemError(results[i], err)
+			continue
+		}
+		dbs = append(dbs, db)
+		indexes = append(indexes, i)
+	}
+
+	if !req.BestEffort && len(dbs) != len(req.PhoneRecords) {
+		for _, i := range indexes {
+			setBatchItemError(results[i], errBatchAborted)
+		}
+		return batchResponse(results), nil
+	}
+
+	if req.ValidateOnly {
+		for j, db := range dbs {
+			pr := getPhoneRecordPB(db)
+			pr.Id, pr.CreateDate = "", ""
+			results[indexes[j]].PhoneRecord = pr
+		}
+		return batchResponse(results), nil
+	}
+
+	// Create phones, failing records are only reported when the batch is best effort
+	var err error
+	if req.BestEffort {
+		var createErrs []error
+		createErrs, err = pb.repo.TryCreatePhones(ctx, dbs)
+		for j, createErr := range createErrs {
+			switch {
+			case createErr == nil:
+			case errors.Is(createErr, repository.ErrMissingReference):
+				setBatchItemError(results[indexes[j]], errs.WrapMessagef(
+					codes.InvalidArgument, "customer %q does not exist", req.PhoneRecords[indexes[j]].CustId,
+				))
+			default:
+				pb.Logger.Error().Str("method", "BatchCreatePhoneRecords").Str("error", createErr.Error()).Msg("failed to create phone record")
+				setBatchItemError(results[indexes[j]], errs.WrapMessage(codes.Internal, "creating phone record failed"))
+			}
+		}
+	} else {
+		err = pb.repo.CreatePhones(ctx, dbs)
+		if errors.Is(err, repository.ErrMissingReference) {
+			return nil, errs.WrapMessage(codes.InvalidArgument, "customer of a phone record does not exist")
+		}
+	}
+	if err != nil {
+		pb.Logger.Error().Str("method", "BatchCreatePhoneRecords").Str("error", err.Error()).Msg("failed to create phone records")
+		return nil, errs.WrapMessage(codes.Internal, "creating phone records failed")
+	}
+
+	for j, db := range dbs {
+		res := results[indexes[j]]
+		if res.Code == "" {
+			res.RecordId = fmt.Sprint(db.ID)
+			res.PhoneRecord = getPhoneRecordPB(db)
+		}
+	}
+
+	return batchResponse(results), nil
+}
+
+func (pb *phoneBookAPIServer) BatchDeletePhoneRecords(
+	ctx context.Context, req *phonebook_v1.BatchDeletePhoneRecordsRequest,
+) (*phonebook_v1.BatchPhoneRecordsResponse, error) {
+	// Validate request
+	switch {
+	case req == nil:
+		return nil, errs.WrapMessage(codes.InvalidArgument, "missing batch request")
+	case len(req.RecordIds) == 0:
+		return nil, errs.WrapMessage(codes.InvalidArgument, "missing phone record ids")
+	case len(req.RecordIds) > maxBatchItems:
+		return nil, errs.WrapMessagef(codes.InvalidArgument, "too many phone record ids, at most %d are allowed", maxBatchItems)
+	}
+
+	results := make([]*phonebook_v1.BatchItemResult, len(req.RecordIds))
+
+	// Check the ids, a record is deleted by the first item naming it
+	ids := make([]uint, 0, len(req.RecordIds))
+	items := make(map[uint]*phonebook_v1.BatchItemResult, len(req.RecordIds))
+	for i, recordId := range req.RecordIds {
+		results[i] = &phonebook_v1.BatchItemResult{Index: int32(i), RecordId: recordId}
+		id, ok := parseRecordId(recordId)
+		switch {
+		case recordId == "":
+			setBatchItemError(results[i], errs.WrapMessage(codes.InvalidArgument, "missing phone record id"))
+		case !ok:
+			setBatchItemError(results[i], errs.WrapMessage(codes.NotFound, "record not found"))
+		case items[id] != nil:
+			setBatchItemError(results[i], errs.WrapMessagef(
+				codes.InvalidArgument, "phone record %q repeats item %d", recordId, items[id].Index,
+			))
+		default:
+			items[id] = results[i]
+			ids = append(ids, id)
+		}
+	}
+
+	if !req.BestEffort && len(ids) != len(req.RecordIds) {
+		for _, res := range results {
+			if res.Code == "" {
+				setBatchItemError(res, errBatchAborted)
+			}
+		}
+		return batchResponse(results), nil
+	}
+
+	// Soft delete in one transaction, the records stay in the trash until restored or purged
+	moved, err := pb.repo.DeletePhones(ctx, ids, req.DeletedBy, !req.BestEffort)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		pb.Logger.Error().Str("method", "BatchDeletePhoneRecords").Str("error", err.Error()).Msg("failed to delete phone records")
+		return nil, errs.WrapMessage(codes.Internal, "deleting phone records failed")
+	}
+
+	// Records that were not moved are missing or already in the trash, nothing is moved when they fail the batch
+	for _, id := range moved {
+		if err != nil {
+			setBatchItemError(items[id], errBatchAborted)
+		}
+		delete(items, id)
+	}
+	for _, res := range items {
+		setBatchItemError(res, errs.WrapMessage(codes.NotFound, "record not found"))
+	}
+
+	return batchResponse(results), nil
+}
+
+func setBatchItemError(res *phonebook_v1.BatchItemResult, err error) {
+	st := status.Convert(err)
+	res.Code = st.Code().String()
+	res.Error = st.Message()
+}
+
+func batchResponse(results []*phonebook_v1.BatchItemResult) *phonebook_v1.BatchPhoneRecordsResponse {
+	res := &phonebook_v1.BatchPhoneRecordsResponse{Results: results}
+	for _, item := range results {
+		if item.Code == "" {
+			res.SucceededCount++
+		} else {
+			res.FailedCount++
+		}
+	}
+	return res
+}
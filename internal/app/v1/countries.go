@@ -0,0 +1,350 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gidyon/jumia-exercise/internal/models"
+	"github.com/gidyon/jumia-exercise/internal/repository"
+	phonebook_v1 "github.com/gidyon/jumia-exercise/pkg/api/phonebook/v1"
+	"github.com/gidyon/jumia-exercise/pkg/utils/phoneutils"
+	"github.com/gidyon/micro/utils/errs"
+	"google.golang.org/grpc/codes"
+)
+
+// NewCountryService creates the service managing countries, it shares the countries table with the phonebook service
+func NewCountryService(ctx context.Context, opt *Options) (phonebook_v1.CountryService, error) {
+	pb, err := newPhoneBookAPIServer(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &countryAPIServer{phones: pb}, nil
+}
+
+type countryAPIServer struct {
+	phones *phoneBookAPIServer
+}
+
+// countryUpdateMask lists every field of a country, a new country is set from all of them
+var countryUpdateMask = []string{
+	phonebook_v1.UpdateMaskCountryName,
+	phonebook_v1.UpdateMaskCountryCode,
+	phonebook_v1.UpdateMaskIsoCode,
+	phonebook_v1.UpdateMaskTrunkPrefix,
+	phonebook_v1.UpdateMaskPatterns,
+	phonebook_v1.UpdateMaskMinLength,
+	phonebook_v1.UpdateMaskMaxLength,
+	phonebook_v1.UpdateMaskNumberTypes,
+	phonebook_v1.UpdateMaskOperators,
+	phonebook_v1.UpdateMaskDisabled,
+}
+
+func (cs *countryAPIServer) CreateCountry(
+	ctx context.Context, req *phonebook_v1.Country,
+) (*phonebook_v1.Country, error) {
+	if req == nil {
+		return nil, errs.WrapMessage(codes.InvalidArgument, "missing country")
+	}
+
+	db := &models.Country{}
+	err := setCountryModel(db, req, countryUpdateMask)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cs.checkCountryName(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create country, the name is checked again by the database for countries created meanwhile
+	err = cs.phones.repo.CreateCountry(ctx, db)
+	switch {
+	case errors.Is(err, repository.ErrAlreadyExists):
+		return nil, errs.WrapMessagef(codes.AlreadyExists, "country %q already exists", db.CountryName)
+	case err != nil:
+		cs.phones.Logger.Error().Str("method", "CreateCountry").Str("error", err.Error()).Msg("failed to create country")
+		return nil, errs.WrapMessage(codes.Internal, "creating country failed")
+	}
+
+	cs.reloadRules("CreateCountry")
+
+	return getCountryPB(db), nil
+}
+
+func (cs *countryAPIServer) GetCountry(
+	ctx context.Context, req *phonebook_v1.GetCountryRequest,
+) (*phonebook_v1.Country, error) {
+	if req == nil || req.CountryId == "" {
+		return nil, errs.WrapMessage(codes.InvalidArgument, "missing country id")
+	}
+
+	db, err := cs.getCountry(ctx, req.CountryId)
+	if err != nil {
+		return nil, err
+	}
+
+	return getCountryPB(db), nil
+}
+
+func (cs *countryAPIServer) UpdateCountry(
+	ctx context.Context, req *phonebook_v1.UpdateCountryRequest,
+) (*phonebook_v1.Country, error) {
+	// Validate fields
+	switch {
+	case req == nil:
+		return nil, errs.WrapMessage(codes.InvalidArgument, "missing update request")
+	case req.Country == nil:
+		return nil, errs.WrapMessage(codes.InvalidArgument, "missing country")
+	case req.Country.Id == "":
+		return nil, errs.WrapMessage(codes.InvalidArgument, "missing country id")
+	case len(req.UpdateMask) == 0:
+		return nil, errs.WrapMessage(codes.InvalidArgument, "missing update mask")
+	}
+
+	db, err := cs.getCountry(ctx, req.Country.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply masked fields
+	err = setCountryModel(db, req.Country, req.UpdateMask)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cs.checkCountryName(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update country, the name is checked again by the database for countries created meanwhile
+	err = cs.phones.repo.SaveCountry(ctx, db)
+	switch {
+	case errors.Is(err, repository.ErrAlreadyExists):
+		return nil, errs.WrapMessagef(codes.AlreadyExists, "country %q already exists", db.CountryName)
+	case err != nil:
+		cs.phones.Logger.Error().Str("method", "UpdateCountry").Str("error", err.Error()).Msg("failed to update country")
+		return nil, errs.WrapMessage(codes.Internal, "updating country failed")
+	}
+
+	cs.reloadRules("UpdateCountry")
+
+	return getCountryPB(db), nil
+}
+
+func (cs *countryAPIServer) ListCountries(
+	ctx context.Context, req *phonebook_v1.ListCountriesRequest,
+) (*phonebook_v1.ListCountriesResponse, error) {
+	if req == nil {
+		req = &phonebook_v1.ListCountriesRequest{}
+	}
+
+	dbs, err := cs.phones.repo.ListCountries(ctx, &repository.CountryFilter{EnabledOnly: req.EnabledOnly})
+	if err != nil {
+		return nil, errs.SQLQueryFailed(err, "LIST")
+	}
+
+	res := &phonebook_v1.ListCountriesResponse{
+		Countries: make([]*phonebook_v1.Country, 0, len(dbs)),
+	}
+	for _, db := range dbs {
+		res.Countries = append(res.Countries, getCountryPB(db))
+	}
+
+	return res, nil
+}
+
+func (cs *countryAPIServer) DisableCountry(
+	ctx context.Context, req *phonebook_v1.DisableCountryRequest,
+) (*phonebook_v1.Country, error) {
+	if req == nil || req.CountryId == "" {
+		return nil, errs.WrapMessage(codes.InvalidArgument, "missing country id")
+	}
+
+	db, err := cs.getCountry(ctx, req.CountryId)
+	if err != nil {
+		return nil, err
+	}
+	if db.Disabled {
+		return getCountryPB(db), nil
+	}
+
+	// Disable country, its phone records are kept
+	db.Disabled = true
+	err = cs.phones.repo.SaveCountry(ctx, db)
+	if err != nil {
+		cs.phones.Logger.Error().Str("method", "DisableCountry").Str("error", err.Error()).Msg("failed to disable country")
+		return nil, errs.WrapMessage(codes.Internal, "disabling country failed")
+	}
+
+	cs.reloadRules("DisableCountry")
+
+	return getCountryPB(db), nil
+}
+
+func (cs *countryAPIServer) getCountry(ctx context.Context, countryId string) (*models.Country, error) {
+	id, err := strconv.ParseUint(countryId, 10, 64)
+	if err != nil {
+		return nil, errs.WrapMessagef(codes.NotFound, "country %q not found", countryId)
+	}
+
+	db, err := cs.phones.repo.GetCountry(ctx, uint(id))
+	switch {
+	case err == nil:
+	case errors.Is(err, repository.ErrNotFound):
+		return nil, errs.WrapMessagef(codes.NotFound, "country %q not found", countryId)
+	default:
+		cs.phones.Logger.Error().Str("method", "getCountry").Str("error", err.Error()).Msg("failed to get country")
+		return nil, errs.WrapMessage(codes.Internal, "getting country failed")
+	}
+
+	return db, nil
+}
+
+// checkCountryName makes sure no other country has the name of db, names identify countries in phone records
+func (cs *countryAPIServer) checkCountryName(ctx context.Context, db *models.Country) error {
+	countries, err := cs.phones.repo.ListCountries(ctx, &repository.CountryFilter{Name: db.CountryName})
+	if err != nil {
+		return errs.SQLQueryFailed(err, "LIST")
+	}
+	for _, country := range countries {
+		if country.ID != db.ID {
+			return errs.WrapMessagef(codes.AlreadyExists, "country %q already exists", db.CountryName)
+		}
+	}
+	return nil
+}
+
+// reloadRules lets validation pick up country changes, the change is saved even when reloading fails
+func (cs *countryAPIServer) reloadRules(method string) {
+	if cs.phones.ReloadRules == nil {
+		return
+	}
+	if err := cs.phones.ReloadRules(); err != nil {
+		cs.phones.Logger.Error().Str("method", method).Str("error", err.Error()).Msg("failed to reload country rules")
+	}
+}
+
+// setCountryModel copies the masked fields of pb to db, the rules of the result are checked as validation would load them
+func setCountryModel(db *models.Country, pb *phonebook_v1.Country, updateMask []string) error {
+	rule, err := db.Rule()
+	if err != nil {
+		return errs.WrapErrorWithCodeAndMsg(codes.FailedPrecondition, err, "stored country rules are broken, update every field")
+	}
+
+	disabled := db.Disabled
+	for _, path := range updateMask {
+		switch path {
+		case phonebook_v1.UpdateMaskCountryName:
+			rule.CountryName = strings.TrimSpace(pb.CountryName)
+		case phonebook_v1.UpdateMaskCountryCode:
+			rule.DialCode = pb.CountryCode
+		case phonebook_v1.UpdateMaskIsoCode:
+			rule.ISOCode = strings.ToUpper(strings.TrimSpace(pb.IsoCode))
+			if rule.ISOCode != "" && len(rule.ISOCode) != 2 {
+				return errs.WrapMessagef(codes.InvalidArgument, "iso code %q must have 2 letters", pb.IsoCode)
+			}
+		case phonebook_v1.UpdateMaskTrunkPrefix:
+			rule.TrunkPrefix = pb.TrunkPrefix
+		case phonebook_v1.UpdateMaskPatterns:
+			rule.Patterns = pb.Patterns
+		case phonebook_v1.UpdateMaskMinLength:
+			rule.MinLength = pb.MinLength
+		case phonebook_v1.UpdateMaskMaxLength:
+			rule.MaxLength = pb.MaxLength
+		case phonebook_v1.UpdateMaskNumberTypes:
+			rule.NumberTypes = pb.NumberTypes
+		case phonebook_v1.UpdateMaskOperators:
+			rule.Operators = pb.Operators
+		case phonebook_v1.UpdateMaskDisabled:
+			disabled = pb.Disabled
+		default:
+			return errs.WrapMessagef(codes.InvalidArgument, "unknown update mask path %q", path)
+		}
+	}
+
+	// Checked by loading the rule the way the validation registry does
+	_, err = phoneutils.NewRuleRegistry(&phoneutils.RuleSet{Countries: []*phoneutils.CountryRule{rule}})
+	if err != nil {
+		return errs.WrapErrorWithCodeAndMsg(codes.InvalidArgument, err, "incorrect country")
+	}
+
+	id := db.ID
+	*db = *models.CountryFromRule(rule)
+	db.ID = id
+	db.Disabled = disabled
+
+	return nil
+}
+
+func getCountryPB(db *models.Country) *phonebook_v1.Country {
+	pb := &phonebook_v1.Country{
+		Id:          fmt.Sprint(db.ID),
+		CountryName: db.CountryName,
+		CountryCode: db.CountryCode,
+		IsoCode:     db.ISOCode,
+		TrunkPrefix: db.TrunkPrefix,
+		MinLength:   db.MinLength,
+		MaxLength:   db.MaxLength,
+		Disabled:    db.Disabled,
+	}
+
+	// Countries added for old phone records may have no rules
+	if rule, err := db.Rule(); err == nil {
+		pb.Patterns = rule.Patterns
+		pb.NumberTypes = rule.NumberTypes
+		pb.Operators = rule.Operators
+	}
+
+	return pb
+}
+
+// countryByName finds the country called name, countries with validation rules get a row the first time they are used.
+// It returns repository.ErrNotFound for countries without rules.
+func countryByName(ctx context.Context, repo repository.CountryRepository, name string) (*models.Country, error) {
+	name = strings.TrimSpace(name)
+
+	countries, err := repo.ListCountries(ctx, &repository.CountryFilter{Name: name})
+	switch {
+	case err != nil:
+		return nil, err
+	case len(countries) != 0:
+		return countries[0], nil
+	}
+
+	rule, ok := phoneutils.DefaultRegistry.Rule(name)
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	country := models.CountryFromRule(rule)
+	err = repo.CreateCountry(ctx, country)
+	switch {
+	case errors.Is(err, repository.ErrAlreadyExists):
+		// Created by a request running at the same time
+		countries, err = repo.ListCountries(ctx, &repository.CountryFilter{Name: name})
+		switch {
+		case err != nil:
+			return nil, err
+		case len(countries) == 0:
+			return nil, repository.ErrNotFound
+		}
+		return countries[0], nil
+	case err != nil:
+		return nil, err
+	}
+
+	return country, nil
+}
+
+// phoneCountry returns the country loaded with a phone, or an empty country when it was not loaded
+func phoneCountry(db *models.Phone) *models.Country {
+	if db.Country == nil {
+		return &models.Country{}
+	}
+	return db.Country
+}
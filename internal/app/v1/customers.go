@@ -0,0 +1,313 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strconv"
+	"time"
+
+	"github.com/gidyon/jumia-exercise/internal/models"
+	"github.com/gidyon/jumia-exercise/internal/repository"
+	phonebook_v1 "github.com/gidyon/jumia-exercise/pkg/api/phonebook/v1"
+	"github.com/gidyon/jumia-exercise/pkg/utils/phoneutils"
+	"github.com/gidyon/micro/utils/errs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Listings of the customer service, page tokens only work in the listing that created them
+const (
+	listingCustomers      = "customers"
+	listingCustomerPhones = "customer_phones"
+)
+
+// NewCustomerService creates the service managing customers, it shares the phones tables with the phonebook service
+func NewCustomerService(ctx context.Context, opt *Options) (phonebook_v1.CustomerService, error) {
+	pb, err := newPhoneBookAPIServer(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &customerAPIServer{phones: pb}, nil
+}
+
+type customerAPIServer struct {
+	phones *phoneBookAPIServer
+}
+
+func (cs *customerAPIServer) CreateCustomer(
+	ctx context.Context, req *phonebook_v1.Customer,
+) (*phonebook_v1.Customer, error) {
+	if req == nil {
+		return nil, errs.WrapMessage(codes.InvalidArgument, "missing customer")
+	}
+
+	db := &models.Customer{}
+	err := setCustomerModel(db, req, []string{
+		phonebook_v1.UpdateMaskName, phonebook_v1.UpdateMaskEmail, phonebook_v1.UpdateMaskMetadata,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Create customer
+	err = cs.phones.repo.CreateCustomer(ctx, db)
+	if err != nil {
+		cs.phones.Logger.Error().Str("method", "CreateCustomer").Str("error", err.Error()).Msg("failed to create customer")
+		return nil, errs.WrapMessage(codes.Internal, "creating customer failed")
+	}
+
+	return getCustomerPB(db), nil
+}
+
+func (cs *customerAPIServer) GetCustomer(
+	ctx context.Context, req *phonebook_v1.GetCustomerRequest,
+) (*phonebook_v1.Customer, error) {
+	if req == nil || req.CustomerId == "" {
+		return nil, errs.WrapMessage(codes.InvalidArgument, "missing customer id")
+	}
+
+	db, err := cs.phones.getCustomer(ctx, req.CustomerId)
+	if err != nil {
+		return nil, err
+	}
+
+	return getCustomerPB(db), nil
+}
+
+func (cs *customerAPIServer) UpdateCustomer(
+	ctx context.Context, req *phonebook_v1.UpdateCustomerRequest,
+) (*phonebook_v1.Customer, error) {
+	// Validate fields
+	switch {
+	case req == nil:
+		return nil, errs.WrapMessage(codes.InvalidArgument, "missing update request")
+	case req.Customer == nil:
+		return nil, errs.WrapMessage(codes.InvalidArgument, "missing customer")
+	case req.Customer.Id == "":
+		return nil, errs.WrapMessage(codes.InvalidArgument, "missing customer id")
+	case len(req.UpdateMask) == 0:
+		return nil, errs.WrapMessage(codes.InvalidArgument, "missing update mask")
+	}
+
+	db, err := cs.phones.getCustomer(ctx, req.Customer.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply masked fields
+	err = setCustomerModel(db, req.Customer, req.UpdateMask)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update customer
+	err = cs.phones.repo.SaveCustomer(ctx, db)
+	if err != nil {
+		cs.phones.Logger.Error().Str("method", "UpdateCustomer").Str("error", err.Error()).Msg("failed to update customer")
+		return nil, errs.WrapMessage(codes.Internal, "updating customer failed")
+	}
+
+	return getCustomerPB(db), nil
+}
+
+func (cs *customerAPIServer) ListCustomers(
+	ctx context.Context, req *phonebook_v1.ListCustomersRequest,
+) (*phonebook_v1.ListCustomersResponse, error) {
+	if req == nil {
+		req = &phonebook_v1.ListCustomersRequest{}
+	}
+
+	pageSize := req.PageSize
+	if pageSize <= 0 || pageSize > defaultPageSize {
+		pageSize = defaultPageSize
+	}
+
+	token := &phoneutils.PageToken{}
+
+	// Newest customers first, the token holds the id of the last customer sent
+	if req.PageToken != "" {
+		var err error
+		token, err = cs.phones.pageTokens.Decode(req.PageToken)
+		if err != nil || token.FilterHash != listingCustomers {
+			return nil, errs.WrapMessage(codes.InvalidArgument, "incorrect page token")
+		}
+	} else {
+		collectionCount, err := cs.phones.repo.CountCustomers(ctx)
+		if err != nil {
+			return nil, errs.SQLQueryFailed(err, "COUNT")
+		}
+		token.CollectionCount = int32(collectionCount)
+		token.PageNumber = 1
+	}
+
+	dbs, err := cs.phones.repo.ListCustomers(ctx, token.Cursor, int(pageSize+1))
+	if err != nil {
+		return nil, errs.SQLQueryFailed(err, "LIST")
+	}
+
+	res := &phonebook_v1.ListCustomersResponse{
+		Customers:       make([]*phonebook_v1.Customer, 0, len(dbs)),
+		CollectionCount: token.CollectionCount,
+	}
+
+	if len(dbs) > int(pageSize) {
+		dbs = dbs[:pageSize]
+		res.NextPageToken = cs.phones.pageTokens.Encode(&phoneutils.PageToken{
+			Direction:       phoneutils.PageNext,
+			Cursor:          dbs[len(dbs)-1].ID,
+			PageNumber:      token.PageNumber + 1,
+			CollectionCount: token.CollectionCount,
+			FilterHash:      listingCustomers,
+		})
+	}
+
+	for _, db := range dbs {
+		res.Customers = append(res.Customers, getCustomerPB(db))
+	}
+
+	return res, nil
+}
+
+func (cs *customerAPIServer) DeleteCustomer(
+	ctx context.Context, req *phonebook_v1.DeleteCustomerRequest,
+) error {
+	if req == nil || req.CustomerId == "" {
+		return errs.WrapMessage(codes.InvalidArgument, "missing customer id")
+	}
+
+	db, err := cs.phones.getCustomer(ctx, req.CustomerId)
+	if err != nil {
+		return err
+	}
+
+	// Phone records in the trash still belong to the customer, they may be restored
+	err = cs.phones.repo.DeleteCustomer(ctx, db.ID)
+	switch {
+	case err == nil:
+	case errors.Is(err, repository.ErrNotFound):
+		return errs.WrapMessage(codes.NotFound, "customer not found")
+	case errors.Is(err, repository.ErrReferenced):
+		return errs.WrapMessage(
+			codes.FailedPrecondition, "customer has phone records, delete or move them to another customer first",
+		)
+	default:
+		cs.phones.Logger.Error().Str("method", "DeleteCustomer").Str("error", err.Error()).Msg("failed to delete customer")
+		return errs.WrapMessage(codes.Internal, "deleting customer failed")
+	}
+
+	return nil
+}
+
+func (cs *customerAPIServer) ListCustomerPhones(
+	ctx context.Context, req *phonebook_v1.ListCustomerPhonesRequest,
+) (*phonebook_v1.ListPhoneRecordsResponse, error) {
+	if req == nil || req.CustomerId == "" {
+		return nil, errs.WrapMessage(codes.InvalidArgument, "missing customer id")
+	}
+
+	db, err := cs.phones.getCustomer(ctx, req.CustomerId)
+	if err != nil {
+		return nil, err
+	}
+
+	return cs.phones.listPhoneRecords(ctx, &phonebook_v1.ListPhoneRecordsRequest{
+		PageSize:  req.PageSize,
+		PageToken: req.PageToken,
+		Filters:   &phonebook_v1.PhoneRecordsFilters{CustIds: []string{fmt.Sprint(db.ID)}},
+		OrderBy:   req.OrderBy,
+	}, listingCustomerPhones, repository.NotDeleted)
+}
+
+func (pb *phoneBookAPIServer) getCustomer(ctx context.Context, customerId string) (*models.Customer, error) {
+	id, err := strconv.ParseUint(customerId, 10, 64)
+	if err != nil {
+		return nil, errs.WrapMessagef(codes.NotFound, "customer %q not found", customerId)
+	}
+
+	db, err := pb.repo.GetCustomer(ctx, uint(id))
+	switch {
+	case err == nil:
+	case errors.Is(err, repository.ErrNotFound):
+		return nil, errs.WrapMessagef(codes.NotFound, "customer %q not found", customerId)
+	default:
+		pb.Logger.Error().Str("method", "getCustomer").Str("error", err.Error()).Msg("failed to get customer")
+		return nil, errs.WrapMessage(codes.Internal, "getting customer failed")
+	}
+
+	return db, nil
+}
+
+// checkCustomer makes sure a phone record is not given to a customer that does not exist, records may have no customer.
+// It returns the id as phone records store it. The database still rejects customers deleted after the check.
+func (pb *phoneBookAPIServer) checkCustomer(ctx context.Context, custId string) (*uint, error) {
+	if custId == "" {
+		return nil, nil
+	}
+
+	db, err := pb.getCustomer(ctx, custId)
+	switch {
+	case err == nil:
+		return &db.ID, nil
+	case status.Code(err) == codes.NotFound:
+		return nil, errs.WrapMessagef(codes.InvalidArgument, "customer %q does not exist", custId)
+	default:
+		return nil, err
+	}
+}
+
+// setCustomerModel copies the masked fields of pb to db
+func setCustomerModel(db *models.Customer, pb *phonebook_v1.Customer, updateMask []string) error {
+	for _, path := range updateMask {
+		switch path {
+		case phonebook_v1.UpdateMaskName:
+			if pb.Name == "" {
+				return errs.WrapMessage(codes.InvalidArgument, "missing customer name")
+			}
+			db.Name = pb.Name
+		case phonebook_v1.UpdateMaskEmail:
+			if pb.Email != "" {
+				addr, err := mail.ParseAddress(pb.Email)
+				if err != nil || addr.Address != pb.Email {
+					return errs.WrapMessagef(codes.InvalidArgument, "incorrect customer email %q", pb.Email)
+				}
+			}
+			db.Email = pb.Email
+		case phonebook_v1.UpdateMaskMetadata:
+			db.Metadata = ""
+			if len(pb.Metadata) != 0 {
+				bs, err := json.Marshal(pb.Metadata)
+				if err != nil {
+					return errs.WrapErrorWithCodeAndMsg(codes.InvalidArgument, err, "incorrect customer metadata")
+				}
+				db.Metadata = string(bs)
+			}
+		default:
+			return errs.WrapMessagef(codes.InvalidArgument, "unknown update mask path %q", path)
+		}
+	}
+
+	return nil
+}
+
+func getCustomerPB(db *models.Customer) *phonebook_v1.Customer {
+	pb := &phonebook_v1.Customer{
+		Id:         fmt.Sprint(db.ID),
+		Name:       db.Name,
+		Email:      db.Email,
+		CreateDate: db.CreateDate.UTC().Format(time.RFC3339),
+	}
+
+	if !db.UpdateDate.IsZero() {
+		pb.UpdateDate = db.UpdateDate.UTC().Format(time.RFC3339)
+	}
+
+	if db.Metadata != "" {
+		_ = json.Unmarshal([]byte(db.Metadata), &pb.Metadata)
+	}
+
+	return pb
+}
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"context"
+
+	"github.com/gidyon/jumia-exercise/internal/models"
+	"github.com/gidyon/jumia-exercise/internal/repository"
+	phonebook_v1 "github.com/gidyon/jumia-exercise/pkg/api/phonebook/v1"
+	"github.com/gidyon/micro/utils/errs"
+	"google.golang.org/grpc/codes"
+)
+
+func (pb *phoneBookAPIServer) ExportPhoneRecords(
+	ctx context.Context, req *phonebook_v1.ExportPhoneRecordsRequest, send func(*phonebook_v1.PhoneRecord) error,
+) error {
+	switch {
+	case req == nil:
+		return errs.WrapMessage(codes.InvalidArgument, "missing export request")
+	case send == nil:
+		return errs.WrapMessage(codes.InvalidArgument, "missing send function")
+	}
+
+	order, err := parsePhoneOrder(req.OrderBy)
+	if err != nil {
+		return err
+	}
+
+	filters, err := phoneFilters(req.Filters)
+	if err != nil {
+		return err
+	}
+
+	// Records are streamed so memory use does not grow with the result, send errors are returned as they are
+	var sendErr error
+	err = pb.repo.RangePhones(ctx, &repository.PhoneQuery{Filters: *filters, Order: order.query()}, func(db *models.Phone) error {
+		sendErr = send(getPhoneRecordPB(db))
+		return sendErr
+	})
+	switch {
+	case err == nil:
+		return nil
+	case sendErr != nil:
+		return sendErr
+	default:
+		return errs.SQLQueryFailed(err, "EXPORT")
+	}
+}
@@ -0,0 +1,404 @@
+package app
+
+import (
+	"context"
+
+	phonebook_v1 "github.com/gidyon/jumia-exercise/pkg/api/phonebook/v1"
+	"github.com/gidyon/jumia-exercise/pkg/api/phonebook/v1/phonebookpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// NewPhoneBookGRPCServer exposes a phonebook service over gRPC
+func NewPhoneBookGRPCServer(svc phonebook_v1.PhoneBookService) phonebookpb.PhoneBookServiceServer {
+	return &phoneBookGRPCServer{svc: svc}
+}
+
+type phoneBookGRPCServer struct {
+	phonebookpb.UnimplementedPhoneBookServiceServer
+	svc phonebook_v1.PhoneBookService
+}
+
+func (gs *phoneBookGRPCServer) CreatePhoneRecord(
+	ctx context.Context, req *phonebookpb.PhoneRecord,
+) (*phonebookpb.PhoneRecord, error) {
+	res, err := gs.svc.CreatePhoneRecord(ctx, phoneRecordFromProto(req))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return phoneRecordProto(res), nil
+}
+
+func (gs *phoneBookGRPCServer) GetPhoneRecord(
+	ctx context.Context, req *phonebookpb.GetPhoneRecordRequest,
+) (*phonebookpb.PhoneRecord, error) {
+	res, err := gs.svc.GetPhoneRecord(ctx, &phonebook_v1.GetPhoneRecordRequest{
+		RecordId: req.GetRecordId(),
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return phoneRecordProto(res), nil
+}
+
+func (gs *phoneBookGRPCServer) UpdatePhoneRecord(
+	ctx context.Context, req *phonebookpb.UpdatePhoneRecordRequest,
+) (*phonebookpb.PhoneRecord, error) {
+	res, err := gs.svc.UpdatePhoneRecord(ctx, &phonebook_v1.UpdatePhoneRecordRequest{
+		PhoneRecord: phoneRecordFromProto(req.GetPhoneRecord()),
+		UpdateMask:  req.GetUpdateMask().GetPaths(),
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return phoneRecordProto(res), nil
+}
+
+func (gs *phoneBookGRPCServer) ListPhoneRecords(
+	ctx context.Context, req *phonebookpb.ListPhoneRecordsRequest,
+) (*phonebookpb.ListPhoneRecordsResponse, error) {
+	res, err := gs.svc.ListPhoneRecords(ctx, listRequestFromProto(req))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return listResponseProto(res), nil
+}
+
+func (gs *phoneBookGRPCServer) ExportPhoneRecords(
+	req *phonebookpb.ExportPhoneRecordsRequest, stream phonebookpb.PhoneBookService_ExportPhoneRecordsServer,
+) error {
+	err := gs.svc.ExportPhoneRecords(stream.Context(), &phonebook_v1.ExportPhoneRecordsRequest{
+		Filters: filtersFromProto(req.GetFilters()),
+		OrderBy: req.GetOrderBy(),
+	}, func(pr *phonebook_v1.PhoneRecord) error {
+		return stream.Send(phoneRecordProto(pr))
+	})
+	if err != nil {
+		return grpcError(err)
+	}
+	return nil
+}
+
+func (gs *phoneBookGRPCServer) DeletePhoneRecord(
+	ctx context.Context, req *phonebookpb.DeletePhoneRecordRequest,
+) (*emptypb.Empty, error) {
+	err := gs.svc.DeletePhoneRecord(ctx, &phonebook_v1.DeletePhoneRecordRequest{
+		RecordId:  req.GetRecordId(),
+		DeletedBy: req.GetDeletedBy(),
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (gs *phoneBookGRPCServer) ListDeletedPhoneRecords(
+	ctx context.Context, req *phonebookpb.ListPhoneRecordsRequest,
+) (*phonebookpb.ListPhoneRecordsResponse, error) {
+	res, err := gs.svc.ListDeletedPhoneRecords(ctx, listRequestFromProto(req))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return listResponseProto(res), nil
+}
+
+func (gs *phoneBookGRPCServer) RestorePhoneRecord(
+	ctx context.Context, req *phonebookpb.RestorePhoneRecordRequest,
+) (*phonebookpb.PhoneRecord, error) {
+	res, err := gs.svc.RestorePhoneRecord(ctx, &phonebook_v1.RestorePhoneRecordRequest{
+		RecordId: req.GetRecordId(),
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return phoneRecordProto(res), nil
+}
+
+func (gs *phoneBookGRPCServer) BatchCreatePhoneRecords(
+	ctx context.Context, req *phonebookpb.BatchCreatePhoneRecordsRequest,
+) (*phonebookpb.BatchPhoneRecordsResponse, error) {
+	prs := make([]*phonebook_v1.PhoneRecord, 0, len(req.GetPhoneRecords()))
+	for _, pr := range req.GetPhoneRecords() {
+		prs = append(prs, phoneRecordFromProto(pr))
+	}
+
+	res, err := gs.svc.BatchCreatePhoneRecords(ctx, &phonebook_v1.BatchCreatePhoneRecordsRequest{
+		PhoneRecords: prs,
+		BestEffort:   req.GetBestEffort(),
+		ValidateOnly: req.GetValidateOnly(),
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return batchResponseProto(res), nil
+}
+
+func (gs *phoneBookGRPCServer) BatchDeletePhoneRecords(
+	ctx context.Context, req *phonebookpb.BatchDeletePhoneRecordsRequest,
+) (*phonebookpb.BatchPhoneRecordsResponse, error) {
+	res, err := gs.svc.BatchDeletePhoneRecords(ctx, &phonebook_v1.BatchDeletePhoneRecordsRequest{
+		RecordIds:  req.GetRecordIds(),
+		DeletedBy:  req.GetDeletedBy(),
+		BestEffort: req.GetBestEffort(),
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return batchResponseProto(res), nil
+}
+
+func (gs *phoneBookGRPCServer) ValidatePhoneNumber(
+	ctx context.Context, req *phonebookpb.ValidatePhoneNumberRequest,
+) (*phonebookpb.ValidatePhoneNumberResponse, error) {
+	res, err := gs.svc.ValidatePhoneNumber(ctx, &phonebook_v1.ValidatePhoneNumberRequest{
+		Number:      req.GetNumber(),
+		CountryName: req.GetCountryName(),
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &phonebookpb.ValidatePhoneNumberResponse{
+		NumberE164: res.NumberE164,
+		Validation: validationResultProto(res.Validation),
+	}, nil
+}
+
+// grpcError passes status errors through unchanged so their codes reach clients
+func grpcError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch err {
+	case context.Canceled:
+		return status.Error(codes.Canceled, err.Error())
+	case context.DeadlineExceeded:
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func listRequestFromProto(req *phonebookpb.ListPhoneRecordsRequest) *phonebook_v1.ListPhoneRecordsRequest {
+	return &phonebook_v1.ListPhoneRecordsRequest{
+		PageSize:  req.GetPageSize(),
+		PageToken: req.GetPageToken(),
+		Filters:   filtersFromProto(req.GetFilters()),
+		OrderBy:   req.GetOrderBy(),
+	}
+}
+
+func filtersFromProto(filters *phonebookpb.PhoneRecordsFilters) *phonebook_v1.PhoneRecordsFilters {
+	if filters == nil {
+		return nil
+	}
+	return &phonebook_v1.PhoneRecordsFilters{
+		CountryCode:      filters.GetCountryCode(),
+		ValidOnly:        filters.GetValidOnly(),
+		NotValidOnly:     filters.GetNotValidOnly(),
+		PhoneNumber:      filters.GetPhoneNumber(),
+		NumberType:       filters.GetNumberType(),
+		Operator:         filters.GetOperator(),
+		PhoneNumberMatch: filters.GetPhoneNumberMatch(),
+		CustIds:          filters.GetCustIds(),
+		CreatedAfter:     filters.GetCreatedAfter(),
+		CreatedBefore:    filters.GetCreatedBefore(),
+	}
+}
+
+func listResponseProto(res *phonebook_v1.ListPhoneRecordsResponse) *phonebookpb.ListPhoneRecordsResponse {
+	pbs := make([]*phonebookpb.PhoneRecord, 0, len(res.PhoneRecords))
+	for _, pr := range res.PhoneRecords {
+		pbs = append(pbs, phoneRecordProto(pr))
+	}
+	return &phonebookpb.ListPhoneRecordsResponse{
+		PhoneRecords:    pbs,
+		NextPageToken:   res.NextPageToken,
+		CollectionCount: res.CollectionCount,
+		PrevPageToken:   res.PrevPageToken,
+		PageNumber:      res.PageNumber,
+	}
+}
+
+func batchResponseProto(res *phonebook_v1.BatchPhoneRecordsResponse) *phonebookpb.BatchPhoneRecordsResponse {
+	results := make([]*phonebookpb.BatchItemResult, 0, len(res.Results))
+	for _, item := range res.Results {
+		result := &phonebookpb.BatchItemResult{
+			Index:    item.Index,
+			RecordId: item.RecordId,
+			Code:     item.Code,
+			Error:    item.Error,
+		}
+		if item.PhoneRecord != nil {
+			result.PhoneRecord = phoneRecordProto(item.PhoneRecord)
+		}
+		results = append(results, result)
+	}
+	return &phonebookpb.BatchPhoneRecordsResponse{
+		Results:        results,
+		SucceededCount: res.SucceededCount,
+		FailedCount:    res.FailedCount,
+	}
+}
+
+func phoneRecordFromProto(pr *phonebookpb.PhoneRecord) *phonebook_v1.PhoneRecord {
+	if pr == nil {
+		return nil
+	}
+	return &phonebook_v1.PhoneRecord{
+		Id:          pr.GetId(),
+		CustId:      pr.GetCustId(),
+		CountryName: pr.GetCountryName(),
+		CountryCode: uint(pr.GetCountryCode()),
+		Number:      pr.GetNumber(),
+	}
+}
+
+func phoneRecordProto(pr *phonebook_v1.PhoneRecord) *phonebookpb.PhoneRecord {
+	return &phonebookpb.PhoneRecord{
+		Id:          pr.Id,
+		CustId:      pr.CustId,
+		CountryName: pr.CountryName,
+		CountryCode: uint32(pr.CountryCode),
+		Number:      pr.Number,
+		NumberE164:  pr.NumberE164,
+		NumberType:  pr.NumberType,
+		Operator:    pr.Operator,
+		PhoneValid:  pr.PhoneValid,
+		Validation:  validationResultProto(pr.Validation),
+		CreateDate:  pr.CreateDate,
+		UpdateDate:  pr.UpdateDate,
+		DeleteDate:  pr.DeleteDate,
+		DeletedBy:   pr.DeletedBy,
+	}
+}
+
+func validationResultProto(res *phonebook_v1.ValidationResult) *phonebookpb.ValidationResult {
+	if res == nil {
+		return nil
+	}
+	return &phonebookpb.ValidationResult{
+		Valid:       res.Valid,
+		CountryName: res.CountryName,
+		CountryCode: uint32(res.CountryCode),
+		NumberType:  res.NumberType,
+		Operator:    res.Operator,
+		Reasons:     res.Reasons,
+		RuleVersion: res.RuleVersion,
+	}
+}
+
+// NewCustomerGRPCServer exposes a customer service over gRPC
+func NewCustomerGRPCServer(svc phonebook_v1.CustomerService) phonebookpb.CustomerServiceServer {
+	return &customerGRPCServer{svc: svc}
+}
+
+type customerGRPCServer struct {
+	phonebookpb.UnimplementedCustomerServiceServer
+	svc phonebook_v1.CustomerService
+}
+
+func (gs *customerGRPCServer) CreateCustomer(
+	ctx context.Context, req *phonebookpb.Customer,
+) (*phonebookpb.Customer, error) {
+	res, err := gs.svc.CreateCustomer(ctx, customerFromProto(req))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return customerProto(res), nil
+}
+
+func (gs *customerGRPCServer) GetCustomer(
+	ctx context.Context, req *phonebookpb.GetCustomerRequest,
+) (*phonebookpb.Customer, error) {
+	res, err := gs.svc.GetCustomer(ctx, &phonebook_v1.GetCustomerRequest{
+		CustomerId: req.GetCustomerId(),
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return customerProto(res), nil
+}
+
+func (gs *customerGRPCServer) UpdateCustomer(
+	ctx context.Context, req *phonebookpb.UpdateCustomerRequest,
+) (*phonebookpb.Customer, error) {
+	res, err := gs.svc.UpdateCustomer(ctx, &phonebook_v1.UpdateCustomerRequest{
+		Customer:   customerFromProto(req.GetCustomer()),
+		UpdateMask: req.GetUpdateMask().GetPaths(),
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return customerProto(res), nil
+}
+
+func (gs *customerGRPCServer) ListCustomers(
+	ctx context.Context, req *phonebookpb.ListCustomersRequest,
+) (*phonebookpb.ListCustomersResponse, error) {
+	res, err := gs.svc.ListCustomers(ctx, &phonebook_v1.ListCustomersRequest{
+		PageSize:  req.GetPageSize(),
+		PageToken: req.GetPageToken(),
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	customers := make([]*phonebookpb.Customer, 0, len(res.Customers))
+	for _, customer := range res.Customers {
+		customers = append(customers, customerProto(customer))
+	}
+	return &phonebookpb.ListCustomersResponse{
+		Customers:       customers,
+		NextPageToken:   res.NextPageToken,
+		CollectionCount: res.CollectionCount,
+	}, nil
+}
+
+func (gs *customerGRPCServer) DeleteCustomer(
+	ctx context.Context, req *phonebookpb.DeleteCustomerRequest,
+) (*emptypb.Empty, error) {
+	err := gs.svc.DeleteCustomer(ctx, &phonebook_v1.DeleteCustomerRequest{
+		CustomerId: req.GetCustomerId(),
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (gs *customerGRPCServer) ListCustomerPhones(
+	ctx context.Context, req *phonebookpb.ListCustomerPhonesRequest,
+) (*phonebookpb.ListPhoneRecordsResponse, error) {
+	res, err := gs.svc.ListCustomerPhones(ctx, &phonebook_v1.ListCustomerPhonesRequest{
+		CustomerId: req.GetCustomerId(),
+		PageSize:   req.GetPageSize(),
+		PageToken:  req.GetPageToken(),
+		OrderBy:    req.GetOrderBy(),
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return listResponseProto(res), nil
+}
+
+func customerFromProto(customer *phonebookpb.Customer) *phonebook_v1.Customer {
+	if customer == nil {
+		return nil
+	}
+	return &phonebook_v1.Customer{
+		Id:       customer.GetId(),
+		Name:     customer.GetName(),
+		Email:    customer.GetEmail(),
+		Metadata: customer.GetMetadata(),
+	}
+}
+
+func customerProto(customer *phonebook_v1.Customer) *phonebookpb.Customer {
+	return &phonebookpb.Customer{
+		Id:         customer.Id,
+		Name:       customer.Name,
+		Email:      customer.Email,
+		Metadata:   customer.Metadata,
+		CreateDate: customer.CreateDate,
+		UpdateDate: customer.UpdateDate,
+	}
+}
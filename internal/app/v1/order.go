@@ -0,0 +1,100 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gidyon/jumia-exercise/internal/models"
+	"github.com/gidyon/jumia-exercise/internal/repository"
+	"github.com/gidyon/jumia-exercise/pkg/utils/phoneutils"
+	"github.com/gidyon/micro/utils/errs"
+	"google.golang.org/grpc/codes"
+)
+
+// defaultOrderBy keeps the newest records first
+const defaultOrderBy = "id desc"
+
+// phoneOrder sorts phones by a column, ties are broken by id in the same direction so the order is total
+type phoneOrder struct {
+	column string
+	desc   bool
+}
+
+// parsePhoneOrder reads an order like "country_name" or "create_date desc", ascending is the default direction
+func parsePhoneOrder(orderBy string) (*phoneOrder, error) {
+	if strings.TrimSpace(orderBy) == "" {
+		orderBy = defaultOrderBy
+	}
+
+	fields := strings.Fields(strings.ToLower(orderBy))
+	order := &phoneOrder{column: fields[0]}
+
+	if repository.SortValue(&models.Phone{}, order.column) == nil {
+		return nil, errs.WrapMessagef(
+			codes.InvalidArgument, "cannot order by %q, order by one of %s", order.column, strings.Join(repository.OrderColumns, ", "),
+		)
+	}
+
+	switch {
+	case len(fields) == 1, len(fields) == 2 && fields[1] == "asc":
+	case len(fields) == 2 && fields[1] == "desc":
+		order.desc = true
+	default:
+		return nil, errs.WrapMessagef(codes.InvalidArgument, "incorrect order %q, use \"<column> [asc|desc]\"", orderBy)
+	}
+
+	return order, nil
+}
+
+func (o *phoneOrder) String() string {
+	if o.desc {
+		return o.column + " desc"
+	}
+	return o.column + " asc"
+}
+
+func (o *phoneOrder) reversed() *phoneOrder {
+	return &phoneOrder{column: o.column, desc: !o.desc}
+}
+
+// query returns the order of repository queries
+func (o *phoneOrder) query() repository.PhoneOrder {
+	return repository.PhoneOrder{Column: o.column, Desc: o.desc}
+}
+
+// cursor returns the sort value of p as stored in page tokens
+func (o *phoneOrder) cursor(p *models.Phone) string {
+	switch v := repository.SortValue(p, o.column).(type) {
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// after returns the position of the token cursor in this order, records after it come next
+func (o *phoneOrder) after(token *phoneutils.PageToken) (*repository.Cursor, error) {
+	cursor := &repository.Cursor{ID: token.Cursor}
+	if o.column == "id" {
+		return cursor, nil
+	}
+
+	var err error
+	switch repository.SortValue(&models.Phone{}, o.column).(type) {
+	case time.Time:
+		cursor.Value, err = time.Parse(time.RFC3339Nano, token.SortValue)
+	case uint:
+		var value uint64
+		value, err = strconv.ParseUint(token.SortValue, 10, 64)
+		cursor.Value = uint(value)
+	default:
+		cursor.Value = token.SortValue
+	}
+	if err != nil {
+		return nil, errs.WrapErrorWithCodeAndMsg(codes.InvalidArgument, err, "incorrect page token")
+	}
+
+	return cursor, nil
+}
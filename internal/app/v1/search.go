@@ -0,0 +1,68 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gidyon/jumia-exercise/internal/models"
+	"github.com/gidyon/jumia-exercise/internal/repository"
+	phonebook_v1 "github.com/gidyon/jumia-exercise/pkg/api/phonebook/v1"
+	"github.com/gidyon/jumia-exercise/pkg/utils/phoneutils"
+	"github.com/gidyon/micro/utils/errs"
+	"google.golang.org/grpc/codes"
+)
+
+// filterPhoneNumber narrows filters to numbers matching the PhoneNumber filter of the request.
+// Prefix and suffix matches run on the search keys of the records, exact matches compare E.164 numbers when they can.
+func filterPhoneNumber(filters *repository.PhoneFilters, req *phonebook_v1.PhoneRecordsFilters) error {
+	if req.PhoneNumber == "" {
+		return nil
+	}
+
+	match := strings.ToLower(req.PhoneNumberMatch)
+	if match == "" || match == phonebook_v1.NumberMatchExact {
+		e164 := phoneutils.NormalizeE164(req.PhoneNumber, "")
+		if e164 == "" && req.CountryCode != "" {
+			e164 = phoneutils.NormalizeE164(fmt.Sprintf("+%s %s", req.CountryCode, req.PhoneNumber), "")
+		}
+		if e164 != "" {
+			filters.NumberMatch, filters.Number = repository.MatchE164, e164
+			return nil
+		}
+		filters.NumberMatch, filters.Number = repository.MatchNumber, req.PhoneNumber
+		return nil
+	}
+
+	digits := phoneutils.SearchDigits(req.PhoneNumber)
+	if digits == "" {
+		return errs.WrapMessagef(codes.InvalidArgument, "phone number filter %q has no digits", req.PhoneNumber)
+	}
+
+	switch match {
+	case phonebook_v1.NumberMatchPrefix:
+		query := strings.TrimSpace(req.PhoneNumber)
+		if strings.HasPrefix(query, "+") || strings.HasPrefix(query, "00") {
+			filters.NumberMatch, filters.Number = repository.MatchE164Prefix, strings.TrimPrefix(digits, "00")
+			return nil
+		}
+		filters.NumberMatch, filters.Number = repository.MatchNationalPrefix, digits
+	case phonebook_v1.NumberMatchSuffix:
+		filters.NumberMatch, filters.Number = repository.MatchSuffix, digits
+	case phonebook_v1.NumberMatchContains:
+		filters.NumberMatch, filters.Number = repository.MatchContains, digits
+	default:
+		return errs.WrapMessagef(
+			codes.InvalidArgument, "unknown phone number match %q, use one of %s, %s, %s or %s", req.PhoneNumberMatch,
+			phonebook_v1.NumberMatchExact, phonebook_v1.NumberMatchPrefix, phonebook_v1.NumberMatchSuffix, phonebook_v1.NumberMatchContains,
+		)
+	}
+
+	return nil
+}
+
+// setSearchKeys fills the columns number searches run on
+func setSearchKeys(db *models.Phone) {
+	keys := phoneutils.NumberSearchKeys(db.Number, phoneCountry(db).CountryName)
+	db.NationalNumber = keys.National
+	db.NumberReversed = keys.Reversed
+}
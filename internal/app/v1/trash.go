@@ -0,0 +1,74 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gidyon/jumia-exercise/internal/repository"
+	phonebook_v1 "github.com/gidyon/jumia-exercise/pkg/api/phonebook/v1"
+	"github.com/gidyon/micro/utils/errs"
+	"google.golang.org/grpc/codes"
+)
+
+// purgeInterval is how often deleted records past the retention window are purged
+const purgeInterval = time.Hour
+
+func (pb *phoneBookAPIServer) ListDeletedPhoneRecords(
+	ctx context.Context, req *phonebook_v1.ListPhoneRecordsRequest,
+) (*phonebook_v1.ListPhoneRecordsResponse, error) {
+	return pb.listPhoneRecords(ctx, req, listingDeletedPhones, repository.OnlyDeleted)
+}
+
+func (pb *phoneBookAPIServer) RestorePhoneRecord(
+	ctx context.Context, req *phonebook_v1.RestorePhoneRecordRequest,
+) (*phonebook_v1.PhoneRecord, error) {
+	switch {
+	case req == nil:
+		return nil, errs.WrapMessage(codes.InvalidArgument, "missing restore request")
+	case req.RecordId == "":
+		return nil, errs.WrapMessage(codes.InvalidArgument, "missing phone record id")
+	}
+
+	id, ok := parseRecordId(req.RecordId)
+	if !ok {
+		return nil, errs.WrapMessage(codes.NotFound, "record not found in trash")
+	}
+
+	// Restore from trash
+	err := pb.repo.RestorePhone(ctx, id)
+	switch {
+	case err == nil:
+	case errors.Is(err, repository.ErrNotFound):
+		return nil, errs.WrapMessage(codes.NotFound, "record not found in trash")
+	default:
+		pb.Logger.Error().Str("method", "RestorePhoneRecord").Str("error", err.Error()).Msg("failed to restore phone record")
+		return nil, errs.WrapMessage(codes.Internal, "restoring phone record failed")
+	}
+
+	return pb.GetPhoneRecord(ctx, &phonebook_v1.GetPhoneRecordRequest{RecordId: req.RecordId})
+}
+
+// purgeWorker purges expired records from the trash until ctx is done
+func (pb *phoneBookAPIServer) purgeWorker(ctx context.Context) {
+	ticker := time.NewTicker(purgeInterval)
+	defer ticker.Stop()
+
+	for {
+		_, err := pb.purgeDeleted(ctx, time.Now().Add(-pb.TrashRetention))
+		if err != nil {
+			pb.Logger.Error().Str("method", "purgeWorker").Str("error", err.Error()).Msg("failed to purge deleted phone records")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purgeDeleted permanently removes records deleted before the given time
+func (pb *phoneBookAPIServer) purgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	return pb.repo.PurgePhones(ctx, before)
+}
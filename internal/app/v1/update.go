@@ -0,0 +1,133 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gidyon/jumia-exercise/internal/models"
+	"github.com/gidyon/jumia-exercise/internal/repository"
+	phonebook_v1 "github.com/gidyon/jumia-exercise/pkg/api/phonebook/v1"
+	"github.com/gidyon/micro/utils/errs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (pb *phoneBookAPIServer) UpdatePhoneRecord(
+	ctx context.Context, req *phonebook_v1.UpdatePhoneRecordRequest,
+) (*phonebook_v1.PhoneRecord, error) {
+	// Validate fields
+	switch {
+	case req == nil:
+		return nil, errs.WrapMessage(codes.InvalidArgument, "missing update request")
+	case req.PhoneRecord == nil:
+		return nil, errs.WrapMessage(codes.InvalidArgument, "missing phonebook")
+	case req.PhoneRecord.Id == "":
+		return nil, errs.WrapMessage(codes.InvalidArgument, "missing phone record id")
+	case len(req.UpdateMask) == 0:
+		return nil, errs.WrapMessage(codes.InvalidArgument, "missing update mask")
+	}
+
+	id, ok := parseRecordId(req.PhoneRecord.Id)
+	if !ok {
+		return nil, errs.WrapMessage(codes.NotFound, "record not found")
+	}
+
+	// Read the masked fields. Countries may be created, so they are resolved before the record is locked.
+	var (
+		updateNumber, updateCountry, updateCustomer bool
+		country                                     *models.Country
+		customerID                                  *uint
+		err                                         error
+	)
+	for _, path := range req.UpdateMask {
+		switch path {
+		case phonebook_v1.UpdateMaskNumber:
+			if req.PhoneRecord.Number == "" {
+				return nil, errs.WrapMessage(codes.InvalidArgument, "missing phone number")
+			}
+			updateNumber = true
+		case phonebook_v1.UpdateMaskCountry:
+			updateCountry = true
+			if req.PhoneRecord.CountryName != "" {
+				country, err = pb.resolveCountry(ctx, req.PhoneRecord.CountryName, "")
+				if err != nil {
+					return nil, err
+				}
+			}
+		case phonebook_v1.UpdateMaskCustId:
+			updateCustomer = true
+			customerID, err = pb.checkCustomer(ctx, req.PhoneRecord.CustId)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, errs.WrapMessagef(codes.InvalidArgument, "unknown update mask path %q", path)
+		}
+	}
+
+	// A missing country name is detected from the dial code in the number, the stored one when it is not updated
+	var detectedFrom string
+	if updateCountry && country == nil {
+		detectedFrom = req.PhoneRecord.Number
+		if !updateNumber {
+			db, err := pb.repo.GetPhone(ctx, id)
+			switch {
+			case errors.Is(err, repository.ErrNotFound):
+				return nil, errs.WrapMessage(codes.NotFound, "record not found")
+			case err != nil:
+				pb.Logger.Error().Str("method", "UpdatePhoneRecord").Str("error", err.Error()).Msg("failed to get phone record")
+				return nil, errs.WrapMessage(codes.Internal, "updating phone record failed")
+			}
+			detectedFrom = db.Number
+		}
+		country, err = pb.resolveCountry(ctx, "", detectedFrom)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Apply them to the record as it is stored when the update runs
+	var updated *models.Phone
+	err = pb.repo.UpdatePhone(ctx, id, func(db *models.Phone) error {
+		if detectedFrom != "" && !updateNumber && db.Number != detectedFrom {
+			return errs.WrapMessage(codes.Aborted, "phone number changed while updating, try again")
+		}
+		if updateNumber {
+			db.Number = req.PhoneRecord.Number
+		}
+		if updateCustomer {
+			db.CustomerID = customerID
+		}
+
+		if updateNumber || updateCountry {
+			country := country
+			switch {
+			case country != nil:
+			case phoneCountry(db).Disabled:
+				return errs.WrapMessagef(codes.InvalidArgument, "country %q is disabled", phoneCountry(db).CountryName)
+			default:
+				country = phoneCountry(db)
+			}
+			db.CountryID = country.ID
+			db.Country = country
+			validatePhoneModel(db)
+		}
+
+		updated = db
+		return nil
+	})
+	switch {
+	case err == nil:
+	case errors.Is(err, repository.ErrNotFound):
+		return nil, errs.WrapMessage(codes.NotFound, "record not found")
+	case errors.Is(err, repository.ErrMissingReference):
+		return nil, errs.WrapMessagef(codes.InvalidArgument, "customer %q does not exist", req.PhoneRecord.CustId)
+	case status.Code(err) != codes.Unknown:
+		return nil, err
+	default:
+		pb.Logger.Error().Str("method", "UpdatePhoneRecord").Str("error", err.Error()).Msg("failed to update phone record")
+		return nil, errs.WrapMessage(codes.Internal, "updating phone record failed")
+	}
+
+	return getPhoneRecordPB(updated), nil
+}
@@ -2,14 +2,30 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand"
+	"net"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/Pallinder/go-randomdata"
+	"github.com/gidyon/jumia-exercise/internal/database"
+	"github.com/gidyon/jumia-exercise/internal/migrations"
+	"github.com/gidyon/jumia-exercise/internal/models"
+	"github.com/gidyon/jumia-exercise/internal/repository"
+	"github.com/gidyon/jumia-exercise/internal/testutil"
 	phonebook_v1 "github.com/gidyon/jumia-exercise/pkg/api/phonebook/v1"
+	"github.com/gidyon/jumia-exercise/pkg/api/phonebook/v1/phonebookpb"
+	"github.com/gidyon/jumia-exercise/pkg/utils/phoneutils"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
@@ -19,21 +35,131 @@ func TestV1(t *testing.T) {
 	RunSpecs(t, "App V1 Suite")
 }
 
-var phoneBookAPI phonebook_v1.PhoneBookService
-
-var _ = BeforeSuite(func() {
-	gormDB, err := gorm.Open(sqlite.Open("phones.db"))
-	Expect(err).ShouldNot(HaveOccurred())
+var (
+	phoneBookAPI phonebook_v1.PhoneBookService
+	customerAPI  phonebook_v1.CustomerService
+	countryAPI   phonebook_v1.CountryService
+	rulesReloads int
+)
 
-	// We use mocks for database
-	phoneBookAPI, err = NewPhoneBookService(context.Background(), &Options{
-		SqlDB:  gormDB,
-		Logger: &zerolog.Logger{},
+// newCustomerId creates a customer for phone records to belong to
+func newCustomerId() string {
+	customer, err := customerAPI.CreateCustomer(context.Background(), &phonebook_v1.Customer{
+		Name: randomdata.FullName(randomdata.RandomGender),
 	})
 	Expect(err).ShouldNot(HaveOccurred())
+	return customer.Id
+}
+
+// randomCountryName picks a country with validation rules
+func randomCountryName() string {
+	rules := phoneutils.DefaultRegistry.Rules()
+	return rules[rand.Intn(len(rules))].CountryName
+}
+
+// suiteBackend holds the services of a test database, or of the in memory repository when it has no database
+type suiteBackend struct {
+	*testutil.Backend
+	phoneBookAPI phonebook_v1.PhoneBookService
+	customerAPI  phonebook_v1.CustomerService
+	countryAPI   phonebook_v1.CountryService
+}
+
+func (b *suiteBackend) String() string {
+	if b.Backend == nil {
+		return "memory"
+	}
+	return b.Driver
+}
+
+var backends []*suiteBackend
+
+// forEachBackend describes the specs once for every test database and the in memory repository,
+// the service globals are those of the running spec
+func forEachBackend(text string, body func()) bool {
+	// sqlite runs in memory so the tests leave no database file behind
+	suiteBackends := []*suiteBackend{{}}
+	for _, backend := range testutil.Backends("file:v1_phones?mode=memory&cache=shared") {
+		suiteBackends = append(suiteBackends, &suiteBackend{Backend: backend})
+	}
+
+	for _, b := range suiteBackends {
+		b := b
+		backends = append(backends, b)
+
+		Describe(fmt.Sprintf("%s on %s", text, b), func() {
+			BeforeEach(func() {
+				phoneBookAPI, customerAPI, countryAPI = b.phoneBookAPI, b.customerAPI, b.countryAPI
+			})
+			body()
+		})
+	}
+	return true
+}
+
+var _ = BeforeSuite(func() {
+	ctx := context.Background()
+
+	for _, b := range backends {
+		// Services on a database use the repository on its tables, like the server does
+		var (
+			gormDB *gorm.DB
+			repo   repository.PhoneRepository
+		)
+		if b.Backend == nil {
+			repo = repository.NewMemory()
+		} else {
+			var err error
+			gormDB, err = database.Open(b.Driver, b.DSN, &gorm.Config{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			// Every run starts from an empty database
+			Expect(gormDB.Migrator().DropTable("phones", "customers", "countries", "schema_migrations")).To(Succeed())
+			_, err = migrations.Up(gormDB, 0)
+			Expect(err).ShouldNot(HaveOccurred())
+		}
+
+		var err error
+		b.phoneBookAPI, err = NewPhoneBookService(ctx, &Options{
+			SqlDB:      gormDB,
+			Repository: repo,
+			Logger:     &zerolog.Logger{},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		b.customerAPI, err = NewCustomerService(ctx, &Options{
+			SqlDB:      gormDB,
+			Repository: repo,
+			Logger:     &zerolog.Logger{},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		b.countryAPI, err = NewCountryService(ctx, &Options{
+			SqlDB:      gormDB,
+			Repository: repo,
+			Logger:     &zerolog.Logger{},
+			ReloadRules: func() error {
+				rulesReloads++
+				return nil
+			},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		// Countries are used for detecting the country of a phone number
+		if repo == nil {
+			repo = repository.NewGorm(gormDB)
+		}
+		for _, rule := range phoneutils.DefaultRegistry.Rules() {
+			countries, err := repo.ListCountries(ctx, &repository.CountryFilter{CountryCode: rule.DialCode})
+			Expect(err).ShouldNot(HaveOccurred())
+			if len(countries) == 0 {
+				Expect(repo.CreateCountry(ctx, models.CountryFromRule(rule))).To(Succeed())
+			}
+		}
+	}
 })
 
-var _ = Describe("Phone Record", func() {
+var _ = forEachBackend("Phone Record", func() {
 
 	Context("Creating a phone record", func() {
 		var (
@@ -43,8 +169,8 @@ var _ = Describe("Phone Record", func() {
 
 		BeforeEach(func() {
 			req = &phonebook_v1.PhoneRecord{
-				CustId:      fmt.Sprint(randomdata.Number(1, 999)),
-				CountryName: randomdata.Country(randomdata.FullCountry),
+				CustId:      newCustomerId(),
+				CountryName: randomCountryName(),
 				CountryCode: 0,
 				Number:      randomdata.PhoneNumber(),
 				PhoneValid:  false,
@@ -58,11 +184,28 @@ var _ = Describe("Phone Record", func() {
 				_, err := phoneBookAPI.CreatePhoneRecord(ctx, req)
 				Expect(err).Should(HaveOccurred())
 			})
-			It("should fail when country is missing", func() {
+			It("should fail when country is missing and dial code is unknown", func() {
 				req.CountryName = ""
+				req.Number = "(999) 697151594"
 				_, err := phoneBookAPI.CreatePhoneRecord(ctx, req)
 				Expect(err).Should(HaveOccurred())
 			})
+			It("should fail when country is missing and number has no dial code", func() {
+				req.CountryName = ""
+				req.Number = "697151594"
+				_, err := phoneBookAPI.CreatePhoneRecord(ctx, req)
+				Expect(err).Should(HaveOccurred())
+			})
+			It("should fail when the customer does not exist", func() {
+				req.CustId = "0"
+				_, err := phoneBookAPI.CreatePhoneRecord(ctx, req)
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
+			It("should fail when the country is not supported", func() {
+				req.CountryName = "Atlantis"
+				_, err := phoneBookAPI.CreatePhoneRecord(ctx, req)
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
 		})
 
 		When("Creating a phone record with valid data", func() {
@@ -72,6 +215,34 @@ var _ = Describe("Phone Record", func() {
 				Expect(pb).ShouldNot(BeNil())
 			})
 		})
+
+		When("Creating a phone record without country", func() {
+			It("should detect the country from the dial code", func() {
+				req.CountryName = ""
+				req.Number = "+256 704123456"
+				pb, err := phoneBookAPI.CreatePhoneRecord(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(pb.CountryName).To(Equal("Uganda"))
+				Expect(pb.CountryCode).To(BeEquivalentTo(256))
+				Expect(pb.PhoneValid).To(BeTrue())
+			})
+		})
+
+		When("Creating a phone record that fails validation", func() {
+			It("should store the failure reasons", func() {
+				req.CountryName = "Cameroon"
+				req.Number = "(237) 997151594"
+				pb, err := phoneBookAPI.CreatePhoneRecord(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(pb.PhoneValid).Should(BeFalse())
+				Expect(pb.Validation).ShouldNot(BeNil())
+				Expect(pb.Validation.Reasons).Should(ConsistOf(phoneutils.ReasonBadPrefix))
+
+				record, err := phoneBookAPI.GetPhoneRecord(ctx, &phonebook_v1.GetPhoneRecordRequest{RecordId: pb.Id})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(record.Validation).Should(Equal(pb.Validation))
+			})
+		})
 	})
 
 	Context("Getting a phone record", func() {
@@ -104,8 +275,8 @@ var _ = Describe("Phone Record", func() {
 			Context("Lets create a phone record", func() {
 				It("should succeed", func() {
 					pb, err = phoneBookAPI.CreatePhoneRecord(ctx, &phonebook_v1.PhoneRecord{
-						CustId:      fmt.Sprint(randomdata.Number(1, 999)),
-						CountryName: randomdata.Country(randomdata.FullCountry),
+						CustId:      newCustomerId(),
+						CountryName: randomCountryName(),
 						CountryCode: 0,
 						Number:      randomdata.PhoneNumber(),
 						PhoneValid:  false,
@@ -129,6 +300,114 @@ var _ = Describe("Phone Record", func() {
 		})
 	})
 
+	Context("Updating a phone record", func() {
+		var (
+			req *phonebook_v1.UpdatePhoneRecordRequest
+			pb  *phonebook_v1.PhoneRecord
+			ctx context.Context
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+
+			var err error
+			pb, err = phoneBookAPI.CreatePhoneRecord(ctx, &phonebook_v1.PhoneRecord{
+				CustId:      newCustomerId(),
+				CountryName: "Cameroon",
+				Number:      "(237) 99715159",
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(pb.PhoneValid).To(BeFalse())
+			Expect(pb.UpdateDate).To(BeEmpty())
+
+			req = &phonebook_v1.UpdatePhoneRecordRequest{
+				PhoneRecord: &phonebook_v1.PhoneRecord{Id: pb.Id},
+			}
+		})
+
+		When("Updating with missing or incorrect data", func() {
+			It("should fail when update mask is missing", func() {
+				_, err := phoneBookAPI.UpdatePhoneRecord(ctx, req)
+				Expect(err).Should(HaveOccurred())
+			})
+			It("should fail when update mask is unknown", func() {
+				req.UpdateMask = []string{"phone_valid"}
+				_, err := phoneBookAPI.UpdatePhoneRecord(ctx, req)
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
+			It("should fail when record does not exist", func() {
+				req.PhoneRecord.Id = "0"
+				req.UpdateMask = []string{phonebook_v1.UpdateMaskCustId}
+				_, err := phoneBookAPI.UpdatePhoneRecord(ctx, req)
+				Expect(status.Code(err)).To(Equal(codes.NotFound))
+			})
+		})
+
+		When("Updating the number", func() {
+			It("should revalidate the phone", func() {
+				req.PhoneRecord.Number = "(237) 697151594"
+				req.PhoneRecord.CustId = "ignored"
+				req.UpdateMask = []string{phonebook_v1.UpdateMaskNumber}
+				updated, err := phoneBookAPI.UpdatePhoneRecord(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(updated.Id).To(Equal(pb.Id))
+				Expect(updated.CreateDate).To(Equal(pb.CreateDate))
+				createDate, err := time.Parse(time.RFC3339, updated.CreateDate)
+				Expect(err).ShouldNot(HaveOccurred())
+				updateDate, err := time.Parse(time.RFC3339, updated.UpdateDate)
+				Expect(err).ShouldNot(HaveOccurred())
+				// Dates are sent in seconds, the repository suite checks the update comes after
+				Expect(updateDate).To(BeTemporally(">=", createDate))
+				Expect(updated.PhoneValid).To(BeTrue())
+				Expect(updated.NumberE164).To(Equal("+237697151594"))
+				Expect(updated.CustId).To(Equal(pb.CustId))
+			})
+		})
+
+		When("Updating the country", func() {
+			It("should revalidate against the new country", func() {
+				req.PhoneRecord.CountryName = "Uganda"
+				req.UpdateMask = []string{phonebook_v1.UpdateMaskCountry}
+				updated, err := phoneBookAPI.UpdatePhoneRecord(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(updated.CountryCode).To(BeEquivalentTo(256))
+				Expect(updated.Validation.Reasons).To(ContainElement(phoneutils.ReasonCountryCodeMismatch))
+			})
+
+			It("should detect a missing country from the stored number", func() {
+				req.PhoneRecord.CountryName = "Uganda"
+				req.UpdateMask = []string{phonebook_v1.UpdateMaskCountry}
+				_, err := phoneBookAPI.UpdatePhoneRecord(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				req.PhoneRecord.CountryName = ""
+				updated, err := phoneBookAPI.UpdatePhoneRecord(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(updated.CountryName).To(Equal("Cameroon"))
+				Expect(updated.Number).To(Equal(pb.Number))
+			})
+		})
+
+		When("Updating the customer id", func() {
+			It("should only change the customer id", func() {
+				custId := newCustomerId()
+				req.PhoneRecord.CustId = custId
+				req.UpdateMask = []string{phonebook_v1.UpdateMaskCustId}
+				updated, err := phoneBookAPI.UpdatePhoneRecord(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(updated.CustId).To(Equal(custId))
+				Expect(updated.Number).To(Equal(pb.Number))
+			})
+
+			It("should fail when the customer does not exist", func() {
+				req.PhoneRecord.CustId = "cust-1"
+				req.UpdateMask = []string{phonebook_v1.UpdateMaskCustId}
+				_, err := phoneBookAPI.UpdatePhoneRecord(ctx, req)
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
+		})
+	})
+
 	Context("Deleting a phone record", func() {
 		var (
 			req *phonebook_v1.DeletePhoneRecordRequest
@@ -149,24 +428,1271 @@ var _ = Describe("Phone Record", func() {
 				Expect(err).Should(HaveOccurred())
 			})
 		})
+
+		When("Deleting an existing phone record", func() {
+			var pb *phonebook_v1.PhoneRecord
+
+			BeforeEach(func() {
+				var err error
+				pb, err = phoneBookAPI.CreatePhoneRecord(ctx, &phonebook_v1.PhoneRecord{
+					CountryName: "Uganda",
+					Number:      "(256) 775069443",
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				req.RecordId = pb.Id
+				req.DeletedBy = "admin"
+				err = phoneBookAPI.DeletePhoneRecord(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+
+			It("should not get or list the record", func() {
+				_, err := phoneBookAPI.GetPhoneRecord(ctx, &phonebook_v1.GetPhoneRecordRequest{RecordId: pb.Id})
+				Expect(status.Code(err)).To(Equal(codes.NotFound))
+
+				res, err := phoneBookAPI.ListPhoneRecords(ctx, &phonebook_v1.ListPhoneRecordsRequest{
+					Filters: &phonebook_v1.PhoneRecordsFilters{PhoneNumber: pb.NumberE164},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				for _, pr := range res.PhoneRecords {
+					Expect(pr.Id).ShouldNot(Equal(pb.Id))
+				}
+			})
+
+			It("should list the record in the trash", func() {
+				res, err := phoneBookAPI.ListDeletedPhoneRecords(ctx, &phonebook_v1.ListPhoneRecordsRequest{
+					Filters: &phonebook_v1.PhoneRecordsFilters{PhoneNumber: pb.NumberE164},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				var found *phonebook_v1.PhoneRecord
+				for _, pr := range res.PhoneRecords {
+					Expect(pr.DeleteDate).ShouldNot(BeEmpty())
+					if pr.Id == pb.Id {
+						found = pr
+					}
+				}
+				Expect(found).ShouldNot(BeNil())
+				Expect(found.DeletedBy).To(Equal("admin"))
+			})
+
+			It("should restore the record", func() {
+				restored, err := phoneBookAPI.RestorePhoneRecord(ctx, &phonebook_v1.RestorePhoneRecordRequest{RecordId: pb.Id})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(restored.Id).To(Equal(pb.Id))
+				Expect(restored.DeleteDate).To(BeEmpty())
+
+				_, err = phoneBookAPI.GetPhoneRecord(ctx, &phonebook_v1.GetPhoneRecordRequest{RecordId: pb.Id})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				_, err = phoneBookAPI.RestorePhoneRecord(ctx, &phonebook_v1.RestorePhoneRecordRequest{RecordId: pb.Id})
+				Expect(status.Code(err)).To(Equal(codes.NotFound))
+			})
+
+			It("should purge the record after the retention window", func() {
+				server := phoneBookAPI.(*phoneBookAPIServer)
+
+				_, err := server.purgeDeleted(ctx, time.Now().Add(-time.Hour))
+				Expect(err).ShouldNot(HaveOccurred())
+				_, err = phoneBookAPI.RestorePhoneRecord(ctx, &phonebook_v1.RestorePhoneRecordRequest{RecordId: pb.Id})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				err = phoneBookAPI.DeletePhoneRecord(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				purged, err := server.purgeDeleted(ctx, time.Now().Add(time.Second))
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(purged).Should(BeNumerically(">=", 1))
+				_, err = phoneBookAPI.RestorePhoneRecord(ctx, &phonebook_v1.RestorePhoneRecordRequest{RecordId: pb.Id})
+				Expect(status.Code(err)).To(Equal(codes.NotFound))
+			})
+		})
 	})
 
-	Context("Listing phone records", func() {
+	Context("Batch creating phone records", func() {
 		var (
-			req *phonebook_v1.ListPhoneRecordsRequest
+			req *phonebook_v1.BatchCreatePhoneRecordsRequest
 			ctx context.Context
 		)
 
 		BeforeEach(func() {
-			req = &phonebook_v1.ListPhoneRecordsRequest{}
+			req = &phonebook_v1.BatchCreatePhoneRecordsRequest{
+				PhoneRecords: []*phonebook_v1.PhoneRecord{
+					{CountryName: "Uganda", Number: "(256) 775069443"},
+					{Number: "+237 697151594"},
+					{CountryName: "Uganda", Number: ""},
+				},
+			}
 			ctx = context.Background()
 		})
 
-		When("Listing phone records with no filters", func() {
-			It("should succeed", func() {
-				_, err := phoneBookAPI.ListPhoneRecords(ctx, req)
+		When("Batch creating with missing phone records", func() {
+			It("should fail", func() {
+				req.PhoneRecords = nil
+				_, err := phoneBookAPI.BatchCreatePhoneRecords(ctx, req)
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
+		})
+
+		When("An item fails in all-or-nothing mode", func() {
+			It("should create nothing", func() {
+				res, err := phoneBookAPI.BatchCreatePhoneRecords(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(res.SucceededCount).To(BeZero())
+				Expect(res.FailedCount).To(BeEquivalentTo(3))
+				Expect(res.Results[0].Code).To(Equal(codes.Aborted.String()))
+				Expect(res.Results[0].PhoneRecord).To(BeNil())
+				Expect(res.Results[2].Code).To(Equal(codes.InvalidArgument.String()))
+			})
+		})
+
+		When("An item fails in best-effort mode", func() {
+			It("should create the other items", func() {
+				req.BestEffort = true
+				res, err := phoneBookAPI.BatchCreatePhoneRecords(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(res.SucceededCount).To(BeEquivalentTo(2))
+				Expect(res.FailedCount).To(BeEquivalentTo(1))
+				Expect(res.Results[2].Code).To(Equal(codes.InvalidArgument.String()))
+
+				detected := res.Results[1].PhoneRecord
+				Expect(detected).ShouldNot(BeNil())
+				Expect(detected.CountryName).To(Equal("Cameroon"))
+				Expect(detected.PhoneValid).To(BeTrue())
+
+				for _, item := range res.Results[:2] {
+					Expect(item.Code).To(BeEmpty())
+					pr, err := phoneBookAPI.GetPhoneRecord(ctx, &phonebook_v1.GetPhoneRecordRequest{RecordId: item.RecordId})
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(pr.Number).To(Equal(req.PhoneRecords[item.Index].Number))
+				}
+			})
+		})
+
+		When("Only validating the items", func() {
+			It("should check them without creating records", func() {
+				req.BestEffort = true
+				req.ValidateOnly = true
+				req.PhoneRecords[0].CustId = "0"
+				listReq := &phonebook_v1.ListPhoneRecordsRequest{
+					Filters: &phonebook_v1.PhoneRecordsFilters{PhoneNumber: "+237 697151594"},
+				}
+				before, err := phoneBookAPI.ListPhoneRecords(ctx, listReq)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				res, err := phoneBookAPI.BatchCreatePhoneRecords(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(res.SucceededCount).To(BeEquivalentTo(1))
+				Expect(res.Results[0].Code).To(Equal(codes.InvalidArgument.String()))
+				Expect(res.Results[2].Code).To(Equal(codes.InvalidArgument.String()))
+
+				detected := res.Results[1].PhoneRecord
+				Expect(res.Results[1].RecordId).To(BeEmpty())
+				Expect(detected.Id).To(BeEmpty())
+				Expect(detected.CountryName).To(Equal("Cameroon"))
+				Expect(detected.NumberE164).To(Equal("+237697151594"))
+
+				after, err := phoneBookAPI.ListPhoneRecords(ctx, listReq)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(after.CollectionCount).To(Equal(before.CollectionCount))
+			})
+		})
+
+		When("All items are valid", func() {
+			It("should create them all", func() {
+				req.PhoneRecords = req.PhoneRecords[:2]
+				res, err := phoneBookAPI.BatchCreatePhoneRecords(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(res.SucceededCount).To(BeEquivalentTo(2))
+				Expect(res.Results[0].RecordId).ShouldNot(Equal(res.Results[1].RecordId))
+			})
+		})
+	})
+
+	Context("Batch deleting phone records", func() {
+		var (
+			req *phonebook_v1.BatchDeletePhoneRecordsRequest
+			ctx context.Context
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			res, err := phoneBookAPI.BatchCreatePhoneRecords(ctx, &phonebook_v1.BatchCreatePhoneRecordsRequest{
+				PhoneRecords: []*phonebook_v1.PhoneRecord{
+					{CountryName: "Uganda", Number: "(256) 775069443"},
+					{CountryName: "Uganda", Number: "(256) 704244430"},
+				},
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			req = &phonebook_v1.BatchDeletePhoneRecordsRequest{
+				RecordIds: []string{res.Results[0].RecordId, res.Results[1].RecordId, "0"},
+				DeletedBy: "admin",
+			}
+		})
+
+		When("Batch deleting with missing record ids", func() {
+			It("should fail", func() {
+				req.RecordIds = nil
+				_, err := phoneBookAPI.BatchDeletePhoneRecords(ctx, req)
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
+		})
+
+		When("An item fails in all-or-nothing mode", func() {
+			It("should delete nothing", func() {
+				res, err := phoneBookAPI.BatchDeletePhoneRecords(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(res.SucceededCount).To(BeZero())
+				Expect(res.Results[2].Code).To(Equal(codes.NotFound.String()))
+
+				_, err = phoneBookAPI.GetPhoneRecord(ctx, &phonebook_v1.GetPhoneRecordRequest{RecordId: req.RecordIds[0]})
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+		})
+
+		When("An item fails in best-effort mode", func() {
+			It("should delete the other items", func() {
+				req.BestEffort = true
+				res, err := phoneBookAPI.BatchDeletePhoneRecords(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(res.SucceededCount).To(BeEquivalentTo(2))
+				Expect(res.FailedCount).To(BeEquivalentTo(1))
+
+				for _, id := range req.RecordIds[:2] {
+					_, err = phoneBookAPI.GetPhoneRecord(ctx, &phonebook_v1.GetPhoneRecordRequest{RecordId: id})
+					Expect(status.Code(err)).To(Equal(codes.NotFound))
+				}
+			})
+		})
+
+		When("An id is repeated", func() {
+			It("should delete the record for the first item only", func() {
+				req.BestEffort = true
+				req.RecordIds = []string{req.RecordIds[0], req.RecordIds[1], req.RecordIds[0]}
+				res, err := phoneBookAPI.BatchDeletePhoneRecords(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(res.SucceededCount).To(BeEquivalentTo(2))
+				Expect(res.Results[0].Code).To(BeEmpty())
+				Expect(res.Results[2].Code).To(Equal(codes.InvalidArgument.String()))
+			})
+
+			It("should delete nothing in all-or-nothing mode", func() {
+				req.RecordIds = []string{req.RecordIds[0], req.RecordIds[0]}
+				res, err := phoneBookAPI.BatchDeletePhoneRecords(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(res.SucceededCount).To(BeZero())
+				Expect(res.Results[0].Code).To(Equal(codes.Aborted.String()))
+
+				_, err = phoneBookAPI.GetPhoneRecord(ctx, &phonebook_v1.GetPhoneRecordRequest{RecordId: req.RecordIds[0]})
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+		})
+
+		When("A record is deleted by someone else first", func() {
+			It("should only report the records this batch deleted", func() {
+				err := phoneBookAPI.DeletePhoneRecord(ctx, &phonebook_v1.DeletePhoneRecordRequest{RecordId: req.RecordIds[1]})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				req.BestEffort = true
+				res, err := phoneBookAPI.BatchDeletePhoneRecords(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(res.SucceededCount).To(BeEquivalentTo(1))
+				Expect(res.Results[1].Code).To(Equal(codes.NotFound.String()))
+
+				req.BestEffort = false
+				req.RecordIds = req.RecordIds[:2]
+				res, err = phoneBookAPI.BatchDeletePhoneRecords(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(res.SucceededCount).To(BeZero())
+				Expect(res.Results[0].Code).To(Equal(codes.NotFound.String()))
+				Expect(res.Results[1].Code).To(Equal(codes.NotFound.String()))
+			})
+		})
+
+		When("A record is deleted by a concurrent batch", func() {
+			It("should be reported by one batch only", func() {
+				req.BestEffort = true
+				req.RecordIds = req.RecordIds[:1]
+
+				results := make(chan *phonebook_v1.BatchPhoneRecordsResponse, 2)
+				for i := 0; i < 2; i++ {
+					go func() {
+						defer GinkgoRecover()
+						res, err := phoneBookAPI.BatchDeletePhoneRecords(ctx, req)
+						Expect(err).ShouldNot(HaveOccurred())
+						results <- res
+					}()
+				}
+				Expect((<-results).SucceededCount + (<-results).SucceededCount).To(BeEquivalentTo(1))
+			})
+		})
+	})
+
+	Context("Validating a phone number", func() {
+		var (
+			req *phonebook_v1.ValidatePhoneNumberRequest
+			ctx context.Context
+		)
+
+		BeforeEach(func() {
+			req = &phonebook_v1.ValidatePhoneNumberRequest{
+				Number:      "(237) 697151594",
+				CountryName: "Cameroon",
+			}
+			ctx = context.Background()
+		})
+
+		When("Validating with missing number", func() {
+			It("should fail", func() {
+				req.Number = ""
+				_, err := phoneBookAPI.ValidatePhoneNumber(ctx, req)
+				Expect(err).Should(HaveOccurred())
+			})
+		})
+
+		When("Validating a valid number", func() {
+			It("should succeed without creating a record", func() {
+				listReq := &phonebook_v1.ListPhoneRecordsRequest{
+					Filters: &phonebook_v1.PhoneRecordsFilters{PhoneNumber: "+237 697151594"},
+				}
+				before, err := phoneBookAPI.ListPhoneRecords(ctx, listReq)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				res, err := phoneBookAPI.ValidatePhoneNumber(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(res.NumberE164).To(Equal("+237697151594"))
+				Expect(res.Validation.Valid).To(BeTrue())
+				Expect(res.Validation.CountryCode).To(BeEquivalentTo(237))
+
+				after, err := phoneBookAPI.ListPhoneRecords(ctx, listReq)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(after.CollectionCount).To(Equal(before.CollectionCount))
+			})
+		})
+
+		When("Validating a number without country hint", func() {
+			It("should detect the country from the dial code", func() {
+				req.CountryName = ""
+				res, err := phoneBookAPI.ValidatePhoneNumber(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(res.Validation.Valid).To(BeTrue())
+				Expect(res.Validation.CountryName).To(Equal("Cameroon"))
+			})
+		})
+
+		When("Validating an invalid number", func() {
+			It("should give the reasons", func() {
+				req.Number = "(237) 6971515"
+				res, err := phoneBookAPI.ValidatePhoneNumber(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(res.Validation.Valid).To(BeFalse())
+				Expect(res.Validation.Reasons).To(ConsistOf(phoneutils.ReasonWrongLength))
+			})
+		})
+	})
+
+	Context("Exporting phone records", func() {
+		var ctx context.Context
+
+		BeforeEach(func() {
+			ctx = context.Background()
+		})
+
+		When("Exporting with missing send function", func() {
+			It("should fail", func() {
+				err := phoneBookAPI.ExportPhoneRecords(ctx, &phonebook_v1.ExportPhoneRecordsRequest{}, nil)
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
+		})
+
+		When("Exporting with filters", func() {
+			It("should send every matching record except deleted ones", func() {
+				res, err := phoneBookAPI.BatchCreatePhoneRecords(ctx, &phonebook_v1.BatchCreatePhoneRecordsRequest{
+					PhoneRecords: []*phonebook_v1.PhoneRecord{
+						{CountryName: "Mozambique", Number: "(258) 847651504"},
+						{CountryName: "Mozambique", Number: "(258) 847651504"},
+					},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				err = phoneBookAPI.DeletePhoneRecord(ctx, &phonebook_v1.DeletePhoneRecordRequest{RecordId: res.Results[1].RecordId})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				filters := &phonebook_v1.PhoneRecordsFilters{PhoneNumber: "+258847651504"}
+				listRes, err := phoneBookAPI.ListPhoneRecords(ctx, &phonebook_v1.ListPhoneRecordsRequest{Filters: filters})
 				Expect(err).ShouldNot(HaveOccurred())
+
+				exported := make([]*phonebook_v1.PhoneRecord, 0, len(listRes.PhoneRecords))
+				err = phoneBookAPI.ExportPhoneRecords(ctx, &phonebook_v1.ExportPhoneRecordsRequest{Filters: filters}, func(pr *phonebook_v1.PhoneRecord) error {
+					exported = append(exported, pr)
+					return nil
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(exported).To(Equal(listRes.PhoneRecords))
+				ids := make([]string, 0, len(exported))
+				for _, pr := range exported {
+					ids = append(ids, pr.Id)
+				}
+				Expect(ids).To(ContainElement(res.Results[0].RecordId))
+				Expect(ids).NotTo(ContainElement(res.Results[1].RecordId))
+			})
+		})
+
+		When("Sending fails", func() {
+			It("should stop with the error", func() {
+				errStop := fmt.Errorf("stop")
+				sent := 0
+				err := phoneBookAPI.ExportPhoneRecords(ctx, &phonebook_v1.ExportPhoneRecordsRequest{}, func(*phonebook_v1.PhoneRecord) error {
+					sent++
+					return errStop
+				})
+				Expect(err).To(Equal(errStop))
+				Expect(sent).To(Equal(1))
+			})
+		})
+	})
+
+	Context("Listing phone records", func() {
+		var (
+			req *phonebook_v1.ListPhoneRecordsRequest
+			ctx context.Context
+		)
+
+		BeforeEach(func() {
+			req = &phonebook_v1.ListPhoneRecordsRequest{}
+			ctx = context.Background()
+		})
+
+		When("Listing phone records with no filters", func() {
+			It("should succeed", func() {
+				_, err := phoneBookAPI.ListPhoneRecords(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+		})
+
+		When("Listing phone records filtered by number type", func() {
+			It("should only return that number type", func() {
+				req.Filters = &phonebook_v1.PhoneRecordsFilters{NumberType: phoneutils.NumberTypeMobile}
+				res, err := phoneBookAPI.ListPhoneRecords(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				for _, pb := range res.PhoneRecords {
+					Expect(pb.NumberType).To(Equal(phoneutils.NumberTypeMobile))
+				}
+			})
+		})
+
+		When("Listing phone records filtered by operator", func() {
+			It("should only return that operator", func() {
+				_, err := phoneBookAPI.CreatePhoneRecord(ctx, &phonebook_v1.PhoneRecord{
+					CountryName: "Uganda",
+					Number:      "(256) 704123456",
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				req.Filters = &phonebook_v1.PhoneRecordsFilters{Operator: "Airtel"}
+				res, err := phoneBookAPI.ListPhoneRecords(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(res.PhoneRecords).ShouldNot(BeEmpty())
+				for _, pb := range res.PhoneRecords {
+					Expect(pb.Operator).To(Equal("Airtel"))
+				}
+			})
+		})
+
+		When("Listing phone records filtered by number", func() {
+			var number string
+
+			Context("Lets create a phone record", func() {
+				It("should succeed", func() {
+					number = fmt.Sprint(randomdata.Number(600000000, 699999999))
+					pb, err := phoneBookAPI.CreatePhoneRecord(ctx, &phonebook_v1.PhoneRecord{
+						CountryName: "Cameroon",
+						Number:      fmt.Sprintf("(237) %s", number),
+					})
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(pb.NumberE164).To(Equal("+237" + number))
+					Expect(pb.NumberType).To(Equal(phoneutils.NumberTypeMobile))
+				})
+			})
+
+			Context("Filtering with a different number format", func() {
+				It("should match on the canonical number", func() {
+					req.Filters = &phonebook_v1.PhoneRecordsFilters{PhoneNumber: "+237 " + number}
+					res, err := phoneBookAPI.ListPhoneRecords(ctx, req)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(res.PhoneRecords).ShouldNot(BeEmpty())
+					Expect(res.PhoneRecords[0].NumberE164).To(Equal("+237" + number))
+				})
+			})
+		})
+
+		When("Listing phone records filtered by customer and create date", func() {
+			var custIds []string
+
+			BeforeEach(func() {
+				custIds = []string{newCustomerId(), newCustomerId()}
+				for _, custId := range custIds {
+					_, err := phoneBookAPI.CreatePhoneRecord(ctx, &phonebook_v1.PhoneRecord{
+						CustId:      custId,
+						CountryName: "Uganda",
+						Number:      "(256) 704123456",
+					})
+					Expect(err).ShouldNot(HaveOccurred())
+				}
+			})
+
+			It("should return the records of one or more customers", func() {
+				req.Filters = &phonebook_v1.PhoneRecordsFilters{CustIds: custIds[:1]}
+				res, err := phoneBookAPI.ListPhoneRecords(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(res.PhoneRecords).To(HaveLen(1))
+				Expect(res.PhoneRecords[0].CustId).To(Equal(custIds[0]))
+
+				req.Filters = &phonebook_v1.PhoneRecordsFilters{CustIds: custIds}
+				res, err = phoneBookAPI.ListPhoneRecords(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(res.PhoneRecords).To(HaveLen(2))
+
+				// Customer ids are the ids of customer rows
+				req.Filters = &phonebook_v1.PhoneRecordsFilters{CustIds: []string{"cust-1"}}
+				_, err = phoneBookAPI.ListPhoneRecords(ctx, req)
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
+
+			It("should return records created in the range", func() {
+				now := time.Now()
+				req.Filters = &phonebook_v1.PhoneRecordsFilters{
+					CustIds:       custIds,
+					CreatedAfter:  now.Add(-time.Minute).UTC().Format(time.RFC3339),
+					CreatedBefore: now.Add(time.Minute).Format(time.RFC3339),
+				}
+				res, err := phoneBookAPI.ListPhoneRecords(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(res.PhoneRecords).To(HaveLen(2))
+
+				req.Filters.CreatedAfter = now.Add(time.Minute).Format(time.RFC3339)
+				req.Filters.CreatedBefore = ""
+				res, err = phoneBookAPI.ListPhoneRecords(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(res.PhoneRecords).To(BeEmpty())
+
+				req.Filters.CreatedAfter = ""
+				req.Filters.CreatedBefore = now.Add(-time.Hour).Format(time.RFC3339)
+				res, err = phoneBookAPI.ListPhoneRecords(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(res.PhoneRecords).To(BeEmpty())
+			})
+
+			It("should fail for dates that are not RFC3339 or an empty range", func() {
+				req.Filters = &phonebook_v1.PhoneRecordsFilters{CreatedAfter: "2022-03-01"}
+				_, err := phoneBookAPI.ListPhoneRecords(ctx, req)
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+				req.Filters = &phonebook_v1.PhoneRecordsFilters{
+					CreatedAfter:  "2022-03-02T00:00:00Z",
+					CreatedBefore: "2022-03-01T00:00:00Z",
+				}
+				_, err = phoneBookAPI.ListPhoneRecords(ctx, req)
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
+		})
+
+		When("Searching by part of a number", func() {
+			var national string
+
+			BeforeEach(func() {
+				national = fmt.Sprint(randomdata.Number(600000000, 699999999))
+				_, err := phoneBookAPI.CreatePhoneRecord(ctx, &phonebook_v1.PhoneRecord{
+					CountryName: "Cameroon",
+					Number:      fmt.Sprintf("(237) %s", national),
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+
+			search := func(number, match string) []string {
+				req.PageSize = defaultPageSize
+				req.Filters = &phonebook_v1.PhoneRecordsFilters{PhoneNumber: number, PhoneNumberMatch: match}
+				res, err := phoneBookAPI.ListPhoneRecords(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				numbers := make([]string, 0, len(res.PhoneRecords))
+				for _, pr := range res.PhoneRecords {
+					numbers = append(numbers, pr.NumberE164)
+				}
+				return numbers
+			}
+
+			It("should match the start of the national number", func() {
+				numbers := search(national[:3]+"-"+national[3:6], phonebook_v1.NumberMatchPrefix)
+				Expect(numbers).To(ContainElement("+237" + national))
+				for _, number := range numbers {
+					Expect(number).To(ContainSubstring(national[:6]))
+				}
+			})
+
+			It("should match the start of the international number", func() {
+				numbers := search("+237 "+national[:5], phonebook_v1.NumberMatchPrefix)
+				Expect(numbers).To(ContainElement("+237" + national))
+				for _, number := range numbers {
+					Expect(number).To(HavePrefix("+237" + national[:5]))
+				}
+			})
+
+			It("should match the end of the number", func() {
+				numbers := search(national[3:6]+" "+national[6:], phonebook_v1.NumberMatchSuffix)
+				Expect(numbers).To(ContainElement("+237" + national))
+				for _, number := range numbers {
+					Expect(number).To(HaveSuffix(national[3:]))
+				}
+			})
+
+			It("should match digits anywhere in the number", func() {
+				numbers := search(national[2:7], phonebook_v1.NumberMatchContains)
+				Expect(numbers).To(ContainElement("+237" + national))
+				for _, number := range numbers {
+					Expect(number).To(ContainSubstring(national[2:7]))
+				}
+			})
+
+			It("should fail for unknown matches and searches without digits", func() {
+				req.Filters = &phonebook_v1.PhoneRecordsFilters{PhoneNumber: national, PhoneNumberMatch: "fuzzy"}
+				_, err := phoneBookAPI.ListPhoneRecords(ctx, req)
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+				req.Filters = &phonebook_v1.PhoneRecordsFilters{PhoneNumber: "()", PhoneNumberMatch: phonebook_v1.NumberMatchPrefix}
+				_, err = phoneBookAPI.ListPhoneRecords(ctx, req)
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
+		})
+
+		When("Paging through phone records", func() {
+			It("should move forwards and backwards with page tokens", func() {
+				number := fmt.Sprintf("(251) 9%08d", randomdata.Number(0, 99999999))
+				ids := make([]string, 0, 5)
+				for i := 0; i < 5; i++ {
+					pb, err := phoneBookAPI.CreatePhoneRecord(ctx, &phonebook_v1.PhoneRecord{
+						CountryName: "Ethiopia",
+						Number:      number,
+					})
+					Expect(err).ShouldNot(HaveOccurred())
+					ids = append([]string{pb.Id}, ids...)
+				}
+
+				req.PageSize = 2
+				req.Filters = &phonebook_v1.PhoneRecordsFilters{PhoneNumber: number, CountryCode: "251"}
+				pageIds := func(res *phonebook_v1.ListPhoneRecordsResponse) []string {
+					got := make([]string, 0, len(res.PhoneRecords))
+					for _, pr := range res.PhoneRecords {
+						got = append(got, pr.Id)
+					}
+					return got
+				}
+
+				first, err := phoneBookAPI.ListPhoneRecords(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(pageIds(first)).To(Equal(ids[:2]))
+				Expect(first.PageNumber).To(BeEquivalentTo(1))
+				Expect(first.CollectionCount).To(BeEquivalentTo(5))
+				Expect(first.PrevPageToken).To(BeEmpty())
+
+				req.PageToken = first.NextPageToken
+				second, err := phoneBookAPI.ListPhoneRecords(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(pageIds(second)).To(Equal(ids[2:4]))
+				Expect(second.PageNumber).To(BeEquivalentTo(2))
+				Expect(second.CollectionCount).To(BeEquivalentTo(5))
+
+				req.PageToken = second.NextPageToken
+				last, err := phoneBookAPI.ListPhoneRecords(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(pageIds(last)).To(Equal(ids[4:]))
+				Expect(last.PageNumber).To(BeEquivalentTo(3))
+				Expect(last.NextPageToken).To(BeEmpty())
+
+				req.PageToken = last.PrevPageToken
+				back, err := phoneBookAPI.ListPhoneRecords(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(pageIds(back)).To(Equal(ids[2:4]))
+				Expect(back.PageNumber).To(BeEquivalentTo(2))
+
+				req.PageToken = back.PrevPageToken
+				back, err = phoneBookAPI.ListPhoneRecords(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(pageIds(back)).To(Equal(ids[:2]))
+				Expect(back.PageNumber).To(BeEquivalentTo(1))
+				Expect(back.PrevPageToken).To(BeEmpty())
+				Expect(back.NextPageToken).ShouldNot(BeEmpty())
+			})
+
+			It("should reject page tokens reused with a different query", func() {
+				req.PageSize = 1
+				first, err := phoneBookAPI.ListPhoneRecords(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(first.NextPageToken).ShouldNot(BeEmpty())
+
+				req.PageToken = first.NextPageToken
+				_, err = phoneBookAPI.ListPhoneRecords(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				req.Filters = &phonebook_v1.PhoneRecordsFilters{CountryCode: "256"}
+				_, err = phoneBookAPI.ListPhoneRecords(ctx, req)
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+				Expect(status.Convert(err).Message()).To(ContainSubstring("different filters or page size"))
+				Expect(errors.Is(err, phonebook_v1.ErrPageTokenMismatch)).To(BeTrue())
+
+				// Broken tokens and orders are not mistaken for changed filters
+				_, err = phoneBookAPI.ListPhoneRecords(ctx, &phonebook_v1.ListPhoneRecordsRequest{PageToken: "not a token"})
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+				Expect(errors.Is(err, phonebook_v1.ErrPageTokenMismatch)).To(BeFalse())
+				_, err = phoneBookAPI.ListPhoneRecords(ctx, &phonebook_v1.ListPhoneRecordsRequest{
+					PageToken: first.NextPageToken, OrderBy: "colour",
+				})
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+				Expect(errors.Is(err, phonebook_v1.ErrPageTokenMismatch)).To(BeFalse())
+
+				req.Filters = nil
+				req.PageSize = 2
+				_, err = phoneBookAPI.ListPhoneRecords(ctx, req)
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+				req.PageSize = 1
+				_, err = phoneBookAPI.ListDeletedPhoneRecords(ctx, req)
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
+
+			It("should page through records with repeated sort values", func() {
+				numbers := []string{"(251) 911000001", "(251) 911000002"}
+				for i := 0; i < 5; i++ {
+					_, err := phoneBookAPI.CreatePhoneRecord(ctx, &phonebook_v1.PhoneRecord{
+						CountryName: "Ethiopia",
+						Number:      numbers[i%2],
+					})
+					Expect(err).ShouldNot(HaveOccurred())
+				}
+
+				req.Filters = &phonebook_v1.PhoneRecordsFilters{CountryCode: "251"}
+				req.OrderBy = "number desc"
+
+				want := make([]string, 0)
+				err := phoneBookAPI.ExportPhoneRecords(
+					ctx,
+					&phonebook_v1.ExportPhoneRecordsRequest{Filters: req.Filters, OrderBy: req.OrderBy},
+					func(pr *phonebook_v1.PhoneRecord) error {
+						want = append(want, pr.Id)
+						return nil
+					},
+				)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(len(want)).To(BeNumerically(">=", 5))
+
+				req.PageSize = 2
+				pages := make([]*phonebook_v1.ListPhoneRecordsResponse, 0)
+				got := make([]string, 0, len(want))
+				for {
+					res, err := phoneBookAPI.ListPhoneRecords(ctx, req)
+					Expect(err).ShouldNot(HaveOccurred())
+					pages = append(pages, res)
+					for i, pr := range res.PhoneRecords {
+						got = append(got, pr.Id)
+						if i > 0 {
+							Expect(pr.Number <= res.PhoneRecords[i-1].Number).To(BeTrue(), "numbers should be descending")
+						}
+					}
+					if res.NextPageToken == "" {
+						break
+					}
+					req.PageToken = res.NextPageToken
+				}
+				Expect(got).To(Equal(want))
+
+				// Walking back gives the same pages
+				for i := len(pages) - 1; i > 0; i-- {
+					req.PageToken = pages[i].PrevPageToken
+					res, err := phoneBookAPI.ListPhoneRecords(ctx, req)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(res.PhoneRecords).To(Equal(pages[i-1].PhoneRecords))
+					Expect(res.PageNumber).To(Equal(pages[i-1].PageNumber))
+				}
+			})
+
+			It("should reject unknown sort columns and directions", func() {
+				req.OrderBy = "operator"
+				_, err := phoneBookAPI.ListPhoneRecords(ctx, req)
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+				req.OrderBy = "number sideways"
+				_, err = phoneBookAPI.ListPhoneRecords(ctx, req)
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+				req.OrderBy = "number; drop table phones"
+				_, err = phoneBookAPI.ListPhoneRecords(ctx, req)
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
+
+			It("should reject page tokens reused with a different order", func() {
+				req.PageSize = 1
+				req.OrderBy = "create_date asc"
+				first, err := phoneBookAPI.ListPhoneRecords(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				req.PageToken = first.NextPageToken
+				_, err = phoneBookAPI.ListPhoneRecords(ctx, req)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				req.OrderBy = "create_date desc"
+				_, err = phoneBookAPI.ListPhoneRecords(ctx, req)
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
+
+			It("should reject edited page tokens", func() {
+				req.PageToken = "MTA="
+				_, err := phoneBookAPI.ListPhoneRecords(ctx, req)
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
+		})
+	})
+
+	Context("Managing customers", func() {
+		var (
+			customer *phonebook_v1.Customer
+			ctx      context.Context
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+
+			var err error
+			customer, err = customerAPI.CreateCustomer(ctx, &phonebook_v1.Customer{
+				Name:     randomdata.FullName(randomdata.RandomGender),
+				Email:    randomdata.Email(),
+				Metadata: map[string]string{"tier": "gold"},
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should fail to create customers with missing or incorrect data", func() {
+			_, err := customerAPI.CreateCustomer(ctx, &phonebook_v1.Customer{Email: randomdata.Email()})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+			_, err = customerAPI.CreateCustomer(ctx, &phonebook_v1.Customer{Name: "Jane", Email: "not an email"})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+
+		It("should get the customer", func() {
+			got, err := customerAPI.GetCustomer(ctx, &phonebook_v1.GetCustomerRequest{CustomerId: customer.Id})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(got).To(Equal(customer))
+
+			_, err = customerAPI.GetCustomer(ctx, &phonebook_v1.GetCustomerRequest{CustomerId: "0"})
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+		})
+
+		It("should only update masked fields", func() {
+			updated, err := customerAPI.UpdateCustomer(ctx, &phonebook_v1.UpdateCustomerRequest{
+				Customer:   &phonebook_v1.Customer{Id: customer.Id, Name: "Renamed", Email: "ignored"},
+				UpdateMask: []string{phonebook_v1.UpdateMaskName},
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(updated.Name).To(Equal("Renamed"))
+			Expect(updated.Email).To(Equal(customer.Email))
+			Expect(updated.Metadata).To(Equal(customer.Metadata))
+		})
+
+		It("should page through customers", func() {
+			newCustomerId()
+			first, err := customerAPI.ListCustomers(ctx, &phonebook_v1.ListCustomersRequest{PageSize: 1})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(first.Customers).To(HaveLen(1))
+			Expect(first.NextPageToken).ShouldNot(BeEmpty())
+
+			second, err := customerAPI.ListCustomers(ctx, &phonebook_v1.ListCustomersRequest{
+				PageSize:  1,
+				PageToken: first.NextPageToken,
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(second.Customers).To(HaveLen(1))
+			Expect(second.Customers[0].Id).ShouldNot(Equal(first.Customers[0].Id))
+			Expect(second.CollectionCount).To(Equal(first.CollectionCount))
+		})
+
+		It("should list the phones of the customer", func() {
+			for i := 0; i < 2; i++ {
+				_, err := phoneBookAPI.CreatePhoneRecord(ctx, &phonebook_v1.PhoneRecord{
+					CustId:      customer.Id,
+					CountryName: "Uganda",
+					Number:      "(256) 704123456",
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+			}
+
+			res, err := customerAPI.ListCustomerPhones(ctx, &phonebook_v1.ListCustomerPhonesRequest{
+				CustomerId: customer.Id,
+				PageSize:   1,
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res.CollectionCount).To(BeEquivalentTo(2))
+			Expect(res.PhoneRecords[0].CustId).To(Equal(customer.Id))
+
+			// Tokens of the customer listing do not work in other listings
+			_, err = phoneBookAPI.ListPhoneRecords(ctx, &phonebook_v1.ListPhoneRecordsRequest{
+				PageSize:  1,
+				PageToken: res.NextPageToken,
+				Filters:   &phonebook_v1.PhoneRecordsFilters{CustIds: []string{customer.Id}},
+			})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+			_, err = customerAPI.ListCustomerPhones(ctx, &phonebook_v1.ListCustomerPhonesRequest{CustomerId: "0"})
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+		})
+
+		It("should only delete customers without phone records", func() {
+			pb, err := phoneBookAPI.CreatePhoneRecord(ctx, &phonebook_v1.PhoneRecord{
+				CustId:      customer.Id,
+				CountryName: "Uganda",
+				Number:      "(256) 704123456",
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			req := &phonebook_v1.DeleteCustomerRequest{CustomerId: customer.Id}
+			Expect(status.Code(customerAPI.DeleteCustomer(ctx, req))).To(Equal(codes.FailedPrecondition))
+
+			// Trashed records may still be restored to the customer
+			Expect(phoneBookAPI.DeletePhoneRecord(ctx, &phonebook_v1.DeletePhoneRecordRequest{RecordId: pb.Id})).To(Succeed())
+			Expect(status.Code(customerAPI.DeleteCustomer(ctx, req))).To(Equal(codes.FailedPrecondition))
+
+			_, err = phoneBookAPI.UpdatePhoneRecord(ctx, &phonebook_v1.UpdatePhoneRecordRequest{
+				PhoneRecord: &phonebook_v1.PhoneRecord{Id: pb.Id},
+				UpdateMask:  []string{phonebook_v1.UpdateMaskCustId},
+			})
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+
+			_, err = phoneBookAPI.RestorePhoneRecord(ctx, &phonebook_v1.RestorePhoneRecordRequest{RecordId: pb.Id})
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = phoneBookAPI.UpdatePhoneRecord(ctx, &phonebook_v1.UpdatePhoneRecordRequest{
+				PhoneRecord: &phonebook_v1.PhoneRecord{Id: pb.Id},
+				UpdateMask:  []string{phonebook_v1.UpdateMaskCustId},
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(customerAPI.DeleteCustomer(ctx, req)).To(Succeed())
+			Expect(status.Code(customerAPI.DeleteCustomer(ctx, req))).To(Equal(codes.NotFound))
+		})
+	})
+
+	Context("Managing countries", func() {
+		var (
+			country *phonebook_v1.Country
+			ctx     context.Context
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+
+			var err error
+			country, err = countryAPI.CreateCountry(ctx, &phonebook_v1.Country{
+				CountryName: "Wakanda " + randomdata.SillyName(),
+				CountryCode: 998,
+				IsoCode:     "wk",
+				Patterns:    []string{`^\(998\)\ ?[5-9]\d{8}$`},
+				MinLength:   9,
+				MaxLength:   9,
+				NumberTypes: map[string][]string{phoneutils.NumberTypeMobile: {"7"}},
+				Operators:   map[string][]string{"Vibranium": {"71"}},
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		// Only one test country has dial code 998 enabled at a time, other tests never detect it
+		AfterEach(func() {
+			_, err := countryAPI.DisableCountry(ctx, &phonebook_v1.DisableCountryRequest{CountryId: country.Id})
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should fail to create countries with missing or incorrect data", func() {
+			_, err := countryAPI.CreateCountry(ctx, &phonebook_v1.Country{CountryCode: 998, Patterns: []string{`^\d+$`}})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+			_, err = countryAPI.CreateCountry(ctx, &phonebook_v1.Country{
+				CountryName: "Atlantis", CountryCode: 998, Patterns: []string{`^(\d+$`},
+			})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+			_, err = countryAPI.CreateCountry(ctx, &phonebook_v1.Country{
+				CountryName: strings.ToUpper(country.CountryName), CountryCode: 998, Patterns: []string{`^\d+$`},
+			})
+			Expect(status.Code(err)).To(Equal(codes.AlreadyExists))
+		})
+
+		It("should create a country once when it is created at the same time", func() {
+			name := "Atlantis " + randomdata.SillyName()
+			results := make(chan codes.Code, 5)
+			for i := 0; i < cap(results); i++ {
+				go func() {
+					defer GinkgoRecover()
+					_, err := countryAPI.CreateCountry(ctx, &phonebook_v1.Country{
+						CountryName: name, CountryCode: 999, Patterns: []string{`^\d+$`}, Disabled: true,
+					})
+					results <- status.Code(err)
+				}()
+			}
+
+			created := 0
+			for i := 0; i < cap(results); i++ {
+				switch code := <-results; code {
+				case codes.OK:
+					created++
+				default:
+					Expect(code).To(Equal(codes.AlreadyExists))
+				}
+			}
+			Expect(created).To(Equal(1))
+		})
+
+		It("should get and list the country", func() {
+			got, err := countryAPI.GetCountry(ctx, &phonebook_v1.GetCountryRequest{CountryId: country.Id})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(got).To(Equal(country))
+			Expect(got.IsoCode).To(Equal("WK"))
+
+			_, err = countryAPI.GetCountry(ctx, &phonebook_v1.GetCountryRequest{CountryId: "0"})
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+
+			res, err := countryAPI.ListCountries(ctx, &phonebook_v1.ListCountriesRequest{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res.Countries).To(ContainElement(country))
+		})
+
+		It("should only update masked fields", func() {
+			reloads := rulesReloads
+			updated, err := countryAPI.UpdateCountry(ctx, &phonebook_v1.UpdateCountryRequest{
+				Country:    &phonebook_v1.Country{Id: country.Id, MaxLength: 10, CountryCode: 1},
+				UpdateMask: []string{phonebook_v1.UpdateMaskMaxLength},
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(updated.MaxLength).To(Equal(10))
+			Expect(updated.CountryCode).To(Equal(country.CountryCode))
+			Expect(updated.Patterns).To(Equal(country.Patterns))
+			Expect(updated.Operators).To(Equal(country.Operators))
+			Expect(rulesReloads).To(Equal(reloads + 1))
+
+			_, err = countryAPI.UpdateCountry(ctx, &phonebook_v1.UpdateCountryRequest{
+				Country:    &phonebook_v1.Country{Id: country.Id, MinLength: 11},
+				UpdateMask: []string{phonebook_v1.UpdateMaskMinLength},
+			})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+
+		It("should stop new phone records from using disabled countries", func() {
+			pb, err := phoneBookAPI.CreatePhoneRecord(ctx, &phonebook_v1.PhoneRecord{
+				CountryName: country.CountryName,
+				Number:      "(998) 712345678",
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(pb.CountryCode).To(BeEquivalentTo(998))
+
+			disabled, err := countryAPI.DisableCountry(ctx, &phonebook_v1.DisableCountryRequest{CountryId: country.Id})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(disabled.Disabled).To(BeTrue())
+
+			_, err = phoneBookAPI.CreatePhoneRecord(ctx, &phonebook_v1.PhoneRecord{
+				CountryName: country.CountryName,
+				Number:      "(998) 712345678",
+			})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			_, err = phoneBookAPI.CreatePhoneRecord(ctx, &phonebook_v1.PhoneRecord{Number: "+998 712345678"})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+			// Records of disabled countries are kept
+			record, err := phoneBookAPI.GetPhoneRecord(ctx, &phonebook_v1.GetPhoneRecordRequest{RecordId: pb.Id})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(record.CountryName).To(Equal(country.CountryName))
+
+			res, err := countryAPI.ListCountries(ctx, &phonebook_v1.ListCountriesRequest{EnabledOnly: true})
+			Expect(err).ShouldNot(HaveOccurred())
+			for _, enabled := range res.Countries {
+				Expect(enabled.Id).ShouldNot(Equal(country.Id))
+			}
+
+			_, err = countryAPI.UpdateCountry(ctx, &phonebook_v1.UpdateCountryRequest{
+				Country:    &phonebook_v1.Country{Id: country.Id, Disabled: false},
+				UpdateMask: []string{phonebook_v1.UpdateMaskDisabled},
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = phoneBookAPI.CreatePhoneRecord(ctx, &phonebook_v1.PhoneRecord{
+				CountryName: country.CountryName,
+				Number:      "(998) 712345678",
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	Context("Serving over gRPC", func() {
+		var (
+			client    phonebookpb.PhoneBookServiceClient
+			customers phonebookpb.CustomerServiceClient
+			srv       *grpc.Server
+			cc        *grpc.ClientConn
+			ctx       context.Context
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+
+			lis := bufconn.Listen(1024 * 1024)
+			srv = grpc.NewServer()
+			phonebookpb.RegisterPhoneBookServiceServer(srv, NewPhoneBookGRPCServer(phoneBookAPI))
+			phonebookpb.RegisterCustomerServiceServer(srv, NewCustomerGRPCServer(customerAPI))
+			go srv.Serve(lis)
+
+			var err error
+			cc, err = grpc.DialContext(ctx, "bufnet", grpc.WithInsecure(), grpc.WithContextDialer(
+				func(context.Context, string) (net.Conn, error) { return lis.Dial() },
+			))
+			Expect(err).ShouldNot(HaveOccurred())
+			client = phonebookpb.NewPhoneBookServiceClient(cc)
+			customers = phonebookpb.NewCustomerServiceClient(cc)
+		})
+
+		AfterEach(func() {
+			cc.Close()
+			srv.Stop()
+		})
+
+		It("should create and get a phone record", func() {
+			pb, err := client.CreatePhoneRecord(ctx, &phonebookpb.PhoneRecord{
+				CountryName: "Cameroon",
+				Number:      "(237) 697151594",
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(pb.PhoneValid).To(BeTrue())
+
+			record, err := client.GetPhoneRecord(ctx, &phonebookpb.GetPhoneRecordRequest{RecordId: pb.Id})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(record.NumberE164).To(Equal("+237697151594"))
+		})
+
+		It("should create a customer and list its phones", func() {
+			customer, err := customers.CreateCustomer(ctx, &phonebookpb.Customer{
+				Name:     "Jane",
+				Metadata: map[string]string{"tier": "gold"},
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(customer.Metadata).To(HaveKeyWithValue("tier", "gold"))
+
+			_, err = client.CreatePhoneRecord(ctx, &phonebookpb.PhoneRecord{
+				CustId: customer.Id,
+				Number: "+237 697151594",
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			res, err := customers.ListCustomerPhones(ctx, &phonebookpb.ListCustomerPhonesRequest{CustomerId: customer.Id})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res.PhoneRecords).To(HaveLen(1))
+		})
+
+		It("should keep error codes", func() {
+			_, err := client.GetPhoneRecord(ctx, &phonebookpb.GetPhoneRecordRequest{RecordId: "0"})
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+
+			_, err = client.CreatePhoneRecord(ctx, &phonebookpb.PhoneRecord{CountryName: "Cameroon"})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+			_, err = client.ListPhoneRecords(ctx, &phonebookpb.ListPhoneRecordsRequest{PageToken: "not a token"})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+	})
+})
+
+// Migrations of older databases run on their own sqlite databases
+var _ = Describe("Database schema", func() {
+
+	Context("Starting on a database that is not migrated", func() {
+		It("should refuse to create the services", func() {
+			ctx := context.Background()
+
+			emptyDB, err := gorm.Open(sqlite.Open("file:empty_phones?mode=memory&cache=shared"))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			_, err = NewPhoneBookService(ctx, &Options{SqlDB: emptyDB, Logger: &zerolog.Logger{}})
+			Expect(errors.Is(err, migrations.ErrNotMigrated)).To(BeTrue())
+
+			_, err = migrations.Up(emptyDB, 2)
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = NewCountryService(ctx, &Options{SqlDB: emptyDB, Logger: &zerolog.Logger{}})
+			Expect(errors.Is(err, migrations.ErrNotMigrated)).To(BeTrue())
+
+			_, err = migrations.Up(emptyDB, 0)
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = NewCustomerService(ctx, &Options{SqlDB: emptyDB, Logger: &zerolog.Logger{}})
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	Context("Migrating phones that store their country", func() {
+		// legacyPhone is a phones row from before phones referenced countries
+		type legacyPhone struct {
+			ID          uint   `gorm:"primaryKey;autoIncrement"`
+			CountryCode uint   `gorm:"size:16"`
+			CountryName string `gorm:"type:varchar(40)"`
+			Number      string `gorm:"index;type:varchar(20);"`
+			NumberE164  string `gorm:"index;type:varchar(16);"`
+			CustId      string `gorm:"index;type:varchar(32);"`
+			PhoneValid  bool   `gorm:"index"`
+			RuleVersion string `gorm:"type:varchar(20)"`
+			CreateDate  time.Time
+			UpdateDate  time.Time
+			DeletedAt   gorm.DeletedAt `gorm:"index"`
+			DeletedBy   string         `gorm:"type:varchar(32)"`
+		}
+
+		It("should point every record at its country row", func() {
+			ctx := context.Background()
+
+			legacyDB, err := gorm.Open(sqlite.Open("file:legacy_phones?mode=memory&cache=shared"))
+			Expect(err).ShouldNot(HaveOccurred())
+			// Soft deletes are the last change before search keys and country references
+			_, err = migrations.Up(legacyDB, 5)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			records := []*legacyPhone{
+				{CountryCode: 237, CountryName: "Cameroon", Number: "(237) 697151594", NumberE164: "+237697151594"},
+				{CountryCode: 256, CountryName: "Uganda", Number: "(256) 704123456", NumberE164: "+256704123456"},
+				{CountryCode: 999, CountryName: "Atlantis", Number: "(999) 123456"},
+			}
+			records[1].DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+			Expect(legacyDB.Table("phones").Create(records).Error).To(Succeed())
+
+			_, err = migrations.Up(legacyDB, 0)
+			Expect(err).ShouldNot(HaveOccurred())
+			svc, err := NewPhoneBookService(ctx, &Options{SqlDB: legacyDB, Logger: &zerolog.Logger{}})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(legacyDB.Migrator().HasColumn(&models.Phone{}, "country_name")).To(BeFalse())
+			Expect(legacyDB.Migrator().HasConstraint(&models.Phone{}, "Country")).To(BeTrue())
+			Expect(legacyDB.Migrator().HasIndex(&models.Phone{}, "CountryID")).To(BeTrue())
+
+			// Trashed records are migrated too
+			restored, err := svc.RestorePhoneRecord(ctx, &phonebook_v1.RestorePhoneRecordRequest{
+				RecordId: fmt.Sprint(records[1].ID),
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(restored.CountryName).To(Equal("Uganda"))
+			Expect(restored.CountryCode).To(BeEquivalentTo(256))
+
+			// Countries without rules keep their name and dial code
+			unknown, err := svc.GetPhoneRecord(ctx, &phonebook_v1.GetPhoneRecordRequest{RecordId: fmt.Sprint(records[2].ID)})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(unknown.CountryName).To(Equal("Atlantis"))
+			Expect(unknown.CountryCode).To(BeEquivalentTo(999))
+
+			res, err := svc.ListPhoneRecords(ctx, &phonebook_v1.ListPhoneRecordsRequest{
+				Filters: &phonebook_v1.PhoneRecordsFilters{
+					CountryCode: "237", PhoneNumber: "151594", PhoneNumberMatch: phonebook_v1.NumberMatchSuffix,
+				},
+				OrderBy: "country_name",
 			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res.PhoneRecords).To(HaveLen(1))
+			Expect(res.PhoneRecords[0].Id).To(Equal(fmt.Sprint(records[0].ID)))
+			Expect(res.PhoneRecords[0].CountryName).To(Equal("Cameroon"))
 		})
 	})
 })
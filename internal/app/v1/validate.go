@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gidyon/jumia-exercise/internal/models"
+	"github.com/gidyon/jumia-exercise/internal/repository"
+	phonebook_v1 "github.com/gidyon/jumia-exercise/pkg/api/phonebook/v1"
+	"github.com/gidyon/jumia-exercise/pkg/utils/phoneutils"
+	"github.com/gidyon/micro/utils/errs"
+	"google.golang.org/grpc/codes"
+)
+
+var (
+	errUnknownCountry   = errors.New("country could not be detected from phone number")
+	errAmbiguousCountry = errors.New("phone number dial code is shared by several countries")
+)
+
+func (pb *phoneBookAPIServer) ValidatePhoneNumber(
+	ctx context.Context, req *phonebook_v1.ValidatePhoneNumberRequest,
+) (*phonebook_v1.ValidatePhoneNumberResponse, error) {
+	// Validate fields
+	switch {
+	case req == nil:
+		return nil, errs.WrapMessage(codes.InvalidArgument, "missing validate request")
+	case req.Number == "":
+		return nil, errs.WrapMessage(codes.InvalidArgument, "missing phone number")
+	}
+
+	countryName := req.CountryName
+
+	// Detect country when no hint is given
+	if countryName == "" {
+		country, err := pb.detectCountry(ctx, req.Number)
+		switch {
+		case err == nil:
+			countryName = country.CountryName
+		case errors.Is(err, errUnknownCountry), errors.Is(err, errAmbiguousCountry):
+		default:
+			return nil, err
+		}
+	}
+
+	return &phonebook_v1.ValidatePhoneNumberResponse{
+		NumberE164: phoneutils.NormalizeE164(req.Number, countryName),
+		Validation: phoneutils.Validate(req.Number, countryName),
+	}, nil
+}
+
+// detectCountry finds the country whose dial code is written in the phone number
+func (pb *phoneBookAPIServer) detectCountry(ctx context.Context, number string) (*models.Country, error) {
+	pn, err := phoneutils.ParseNumber(number, "")
+	if err != nil || !pn.HasDialCode {
+		return nil, errUnknownCountry
+	}
+
+	countries, err := pb.repo.ListCountries(ctx, &repository.CountryFilter{CountryCode: pn.DialCode, EnabledOnly: true})
+	if err != nil {
+		pb.Logger.Error().Str("method", "detectCountry").Str("error", err.Error()).Msg("failed to get countries")
+		return nil, errs.WrapMessage(codes.Internal, "detecting country failed")
+	}
+
+	switch len(countries) {
+	case 0:
+		return nil, fmt.Errorf("%w: no country has dial code %d", errUnknownCountry, pn.DialCode)
+	case 1:
+		return countries[0], nil
+	default:
+		return nil, fmt.Errorf("%w: dial code %d", errAmbiguousCountry, pn.DialCode)
+	}
+}
@@ -0,0 +1,102 @@
+// Package database opens the sql database phone records are stored in with the configured driver
+package database
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	mysql_driver "github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgconn"
+	"github.com/mattn/go-sqlite3"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// Supported drivers
+const (
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
+	DriverMySQL    = "mysql"
+)
+
+// Drivers lists the supported drivers
+var Drivers = []string{DriverSQLite, DriverPostgres, DriverMySQL}
+
+// Dialector returns the gorm dialector connecting to dsn with driver. The dsn is a file path for sqlite,
+// a connection URL or keyword/value string for PostgreSQL and a go-sql-driver DSN for MySQL.
+func Dialector(driver, dsn string) (gorm.Dialector, error) {
+	switch strings.ToLower(driver) {
+	case DriverSQLite:
+		// sqlite only enforces foreign keys when asked to, on every connection
+		if !strings.Contains(dsn, "_foreign_keys=") && !strings.Contains(dsn, "_fk=") {
+			separator := "?"
+			if strings.Contains(dsn, "?") {
+				separator = "&"
+			}
+			dsn += separator + "_foreign_keys=1"
+		}
+		return sqlite.Open(dsn), nil
+	case DriverPostgres:
+		return postgres.Open(dsn), nil
+	case DriverMySQL:
+		// Dates are scanned into time.Time, which MySQL only does when parsing times
+		cfg, err := mysql_driver.ParseDSN(dsn)
+		if err != nil {
+			return nil, fmt.Errorf("incorrect mysql dsn: %w", err)
+		}
+		cfg.ParseTime = true
+		return mysql.Open(cfg.FormatDSN()), nil
+	default:
+		return nil, fmt.Errorf("unknown database driver %q, use one of %s", driver, strings.Join(Drivers, ", "))
+	}
+}
+
+// Open connects to the database at dsn with driver
+func Open(driver, dsn string, config *gorm.Config) (*gorm.DB, error) {
+	dialector, err := Dialector(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	return gorm.Open(dialector, config)
+}
+
+// IsForeignKeyViolation tells whether err is a driver error for a row referencing a missing row,
+// or for deleting a row other rows still reference
+func IsForeignKeyViolation(err error) bool {
+	var (
+		sqliteErr sqlite3.Error
+		pgErr     *pgconn.PgError
+		mysqlErr  *mysql_driver.MySQLError
+	)
+	switch {
+	case errors.As(err, &sqliteErr):
+		// Restricted deletes fail with the trigger constraint code
+		return sqliteErr.Code == sqlite3.ErrConstraint && strings.Contains(sqliteErr.Error(), "FOREIGN KEY")
+	case errors.As(err, &pgErr):
+		return pgErr.Code == "23503"
+	case errors.As(err, &mysqlErr):
+		return mysqlErr.Number == 1451 || mysqlErr.Number == 1452
+	}
+	return false
+}
+
+// IsUniqueViolation tells whether err is a driver error for a row repeating the value of a unique index
+func IsUniqueViolation(err error) bool {
+	var (
+		sqliteErr sqlite3.Error
+		pgErr     *pgconn.PgError
+		mysqlErr  *mysql_driver.MySQLError
+	)
+	switch {
+	case errors.As(err, &sqliteErr):
+		return sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+	case errors.As(err, &pgErr):
+		return pgErr.Code == "23505"
+	case errors.As(err, &mysqlErr):
+		return mysqlErr.Number == 1062
+	}
+	return false
+}
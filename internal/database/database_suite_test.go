@@ -0,0 +1,85 @@
+package database
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestDatabase(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Database Suite")
+}
+
+var _ = Describe("Database", func() {
+
+	Context("Choosing a dialector", func() {
+		It("should pick the dialector of every driver", func() {
+			for _, driver := range Drivers {
+				dialector, err := Dialector(driver, "root:secret@tcp(localhost:3306)/phonebook")
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(dialector.Name()).To(Equal(driver))
+			}
+		})
+
+		It("should fail for unknown drivers and incorrect mysql dsns", func() {
+			_, err := Dialector("oracle", "phones")
+			Expect(err).Should(HaveOccurred())
+
+			_, err = Dialector(DriverMySQL, "localhost:3306")
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("should parse mysql times", func() {
+			dialector, err := Dialector(DriverMySQL, "root:secret@tcp(localhost:3306)/phonebook")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(dialector.(*mysql.Dialector).DSN).To(ContainSubstring("parseTime=true"))
+		})
+	})
+
+	Context("Enforcing foreign keys", func() {
+		It("should reject rows referencing missing rows and deleting referenced rows in sqlite", func() {
+			db, err := Open(DriverSQLite, "file:foreign_keys?mode=memory&cache=shared", &gorm.Config{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(db.Exec("CREATE TABLE owners (id integer PRIMARY KEY)").Error).To(Succeed())
+			Expect(db.Exec(
+				"CREATE TABLE items (id integer PRIMARY KEY, owner_id integer REFERENCES owners(id) ON DELETE RESTRICT)",
+			).Error).To(Succeed())
+
+			err = db.Exec("INSERT INTO items (id, owner_id) VALUES (1, 1)").Error
+			Expect(IsForeignKeyViolation(err)).To(BeTrue())
+
+			Expect(db.Exec("INSERT INTO owners (id) VALUES (1)").Error).To(Succeed())
+			Expect(db.Exec("INSERT INTO items (id, owner_id) VALUES (1, 1)").Error).To(Succeed())
+			err = db.Exec("DELETE FROM owners WHERE id = 1").Error
+			Expect(IsForeignKeyViolation(err)).To(BeTrue())
+
+			err = db.Exec("INSERT INTO items (id) VALUES (1)").Error
+			Expect(err).Should(HaveOccurred())
+			Expect(IsForeignKeyViolation(err)).To(BeFalse())
+		})
+	})
+
+	Context("Enforcing unique indexes", func() {
+		It("should tell repeated values from other errors in sqlite", func() {
+			db, err := Open(DriverSQLite, "file:unique?mode=memory&cache=shared", &gorm.Config{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(db.Exec("CREATE TABLE names (id integer PRIMARY KEY, name text NOT NULL UNIQUE)").Error).To(Succeed())
+			Expect(db.Exec("INSERT INTO names (id, name) VALUES (1, 'Kenya')").Error).To(Succeed())
+
+			err = db.Exec("INSERT INTO names (id, name) VALUES (2, 'Kenya')").Error
+			Expect(IsUniqueViolation(err)).To(BeTrue())
+			Expect(IsForeignKeyViolation(err)).To(BeFalse())
+
+			err = db.Exec("INSERT INTO names (id) VALUES (3)").Error
+			Expect(err).Should(HaveOccurred())
+			Expect(IsUniqueViolation(err)).To(BeFalse())
+		})
+	})
+
+})
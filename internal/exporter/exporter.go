@@ -0,0 +1,112 @@
+// Package exporter writes phone records as CSV, NDJSON or XLSX files
+package exporter
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	phonebook_v1 "github.com/gidyon/jumia-exercise/pkg/api/phonebook/v1"
+)
+
+// Formats phone records can be exported as
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+	FormatXLSX   = "xlsx"
+)
+
+// Writer writes phone records one at a time, Close must be called to complete the file
+type Writer interface {
+	Write(*phonebook_v1.PhoneRecord) error
+	Close() error
+}
+
+// NewWriter returns a writer for format that writes to w
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w)
+	case FormatNDJSON:
+		return &ndjsonWriter{enc: json.NewEncoder(w)}, nil
+	case FormatXLSX:
+		return newXLSXWriter(w)
+	}
+	return nil, fmt.Errorf("unknown export format %q", format)
+}
+
+// ContentType is the media type of files in format
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "application/octet-stream"
+}
+
+// header names the columns of tabular formats, values come from row
+var header = []string{
+	"id", "cust_id", "country_name", "country_code", "number", "number_e164",
+	"number_type", "operator", "phone_valid", "reasons", "create_date", "update_date",
+}
+
+func row(pr *phonebook_v1.PhoneRecord) []string {
+	var reasons string
+	if pr.Validation != nil {
+		reasons = strings.Join(pr.Validation.Reasons, ",")
+	}
+	return []string{
+		pr.Id,
+		pr.CustId,
+		pr.CountryName,
+		strconv.FormatUint(uint64(pr.CountryCode), 10),
+		pr.Number,
+		pr.NumberE164,
+		pr.NumberType,
+		pr.Operator,
+		strconv.FormatBool(pr.PhoneValid),
+		reasons,
+		pr.CreateDate,
+		pr.UpdateDate,
+	}
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) (*csvWriter, error) {
+	cw := &csvWriter{w: csv.NewWriter(w)}
+	if err := cw.w.Write(header); err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+func (cw *csvWriter) Write(pr *phonebook_v1.PhoneRecord) error {
+	return cw.w.Write(row(pr))
+}
+
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+type ndjsonWriter struct {
+	enc *json.Encoder
+}
+
+func (nw *ndjsonWriter) Write(pr *phonebook_v1.PhoneRecord) error {
+	return nw.enc.Encode(pr)
+}
+
+func (nw *ndjsonWriter) Close() error {
+	return nil
+}
@@ -0,0 +1,105 @@
+package exporter
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	phonebook_v1 "github.com/gidyon/jumia-exercise/pkg/api/phonebook/v1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestExporter(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Exporter Suite")
+}
+
+var records = []*phonebook_v1.PhoneRecord{
+	{
+		Id:          "2",
+		CountryName: "Uganda",
+		CountryCode: 256,
+		Number:      "(256) 775069443",
+		NumberE164:  "+256775069443",
+		NumberType:  "MOBILE",
+		Operator:    "MTN",
+		PhoneValid:  true,
+	},
+	{
+		Id:          "1",
+		CustId:      "a<b>&c",
+		CountryName: "Morocco",
+		CountryCode: 212,
+		Number:      "(212) 6007989253",
+		Validation:  &phonebook_v1.ValidationResult{Reasons: []string{"WRONG_LENGTH", "BAD_PREFIX"}},
+	},
+}
+
+func export(format string) []byte {
+	buf := &bytes.Buffer{}
+	w, err := NewWriter(format, buf)
+	Expect(err).ShouldNot(HaveOccurred())
+	for _, pr := range records {
+		Expect(w.Write(pr)).To(Succeed())
+	}
+	Expect(w.Close()).To(Succeed())
+	return buf.Bytes()
+}
+
+var _ = Describe("Exporting phone records", func() {
+	It("should fail for unknown formats", func() {
+		_, err := NewWriter("pdf", &bytes.Buffer{})
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("should write csv with a header", func() {
+		rows, err := csv.NewReader(bytes.NewReader(export(FormatCSV))).ReadAll()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(rows).To(HaveLen(3))
+		Expect(rows[0]).To(Equal(header))
+		Expect(rows[1][5]).To(Equal("+256775069443"))
+		Expect(rows[1][8]).To(Equal("true"))
+		Expect(rows[2][9]).To(Equal("WRONG_LENGTH,BAD_PREFIX"))
+	})
+
+	It("should write one json record per line", func() {
+		scanner := bufio.NewScanner(bytes.NewReader(export(FormatNDJSON)))
+		got := make([]*phonebook_v1.PhoneRecord, 0, 2)
+		for scanner.Scan() {
+			pr := &phonebook_v1.PhoneRecord{}
+			Expect(json.Unmarshal(scanner.Bytes(), pr)).To(Succeed())
+			got = append(got, pr)
+		}
+		Expect(got).To(Equal(records))
+	})
+
+	It("should write a workbook with one sheet", func() {
+		data := export(FormatXLSX)
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		names := make([]string, 0, len(zr.File))
+		var sheet []byte
+		for _, f := range zr.File {
+			names = append(names, f.Name)
+			if f.Name == "xl/worksheets/sheet1.xml" {
+				rc, err := f.Open()
+				Expect(err).ShouldNot(HaveOccurred())
+				sheet, err = ioutil.ReadAll(rc)
+				Expect(err).ShouldNot(HaveOccurred())
+				rc.Close()
+			}
+		}
+		Expect(names).To(ContainElements("[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels"))
+
+		Expect(bytes.Count(sheet, []byte("<row>"))).To(Equal(3))
+		Expect(string(sheet)).To(ContainSubstring(`<c><v>256</v></c>`))
+		Expect(string(sheet)).To(ContainSubstring(`<c t="b"><v>1</v></c>`))
+		Expect(string(sheet)).To(ContainSubstring(`a&lt;b&gt;&amp;c`))
+	})
+})
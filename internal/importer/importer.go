@@ -0,0 +1,336 @@
+// Package importer imports phone records from CSV files
+package importer
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	phonebook_v1 "github.com/gidyon/jumia-exercise/pkg/api/phonebook/v1"
+)
+
+// Status of an imported row
+const (
+	RowAccepted = "ACCEPTED" // passed checks in a dry run
+	RowCreated  = "CREATED"
+	RowRejected = "REJECTED"
+	RowFailed   = "FAILED" // passed checks but could not be saved
+)
+
+// DefaultBatchSize is the number of accepted rows committed together
+const DefaultBatchSize = 500
+
+// Columns maps record fields to CSV header names, matching ignores case and surrounding spaces
+type Columns struct {
+	Country string
+	Phone   string
+	CustId  string
+}
+
+// DefaultColumns matches the shape of the seed data
+var DefaultColumns = Columns{
+	Country: "country",
+	Phone:   "phone",
+	CustId:  "cust_id",
+}
+
+type Options struct {
+	// Columns defaults to DefaultColumns, a missing country column means countries are detected from the numbers
+	Columns *Columns
+	// DryRun validates rows without saving them
+	DryRun bool
+	// SkipInvalid rejects rows whose number fails validation instead of saving them flagged as not valid
+	SkipInvalid bool
+	// BatchSize defaults to DefaultBatchSize
+	BatchSize int
+	// OnRow is called with the result of each row in file order
+	OnRow func(*RowResult)
+}
+
+// RowResult is the outcome for a data row, rows are numbered from 1 after the header
+type RowResult struct {
+	Row         int      `json:"row"`
+	CountryName string   `json:"country_name,omitempty"`
+	Number      string   `json:"number,omitempty"`
+	NumberE164  string   `json:"number_e164,omitempty"`
+	Valid       bool     `json:"valid"`
+	Reasons     []string `json:"reasons,omitempty"`
+	Status      string   `json:"status"`
+	RecordId    string   `json:"record_id,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+type Report struct {
+	DryRun       bool `json:"dry_run"`
+	TotalRows    int  `json:"total_rows"`
+	AcceptedRows int  `json:"accepted_rows"`
+	CreatedRows  int  `json:"created_rows"`
+	RejectedRows int  `json:"rejected_rows"`
+	FailedRows   int  `json:"failed_rows"`
+}
+
+// ErrMissingColumn is returned when the header lacks the phone column
+var ErrMissingColumn = errors.New("missing column in csv header")
+
+// Import streams phone records from a CSV file with a header row into svc.
+// Rows are checked one at a time and accepted rows are committed in batches, so
+// a failed import keeps the batches that were committed before it failed.
+func Import(ctx context.Context, svc phonebook_v1.PhoneBookService, r io.Reader, opt *Options) (*Report, error) {
+	if opt == nil {
+		opt = &Options{}
+	}
+	columns := opt.Columns
+	if columns == nil {
+		columns = &DefaultColumns
+	}
+	batchSize := opt.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	switch {
+	case err == io.EOF:
+		return nil, fmt.Errorf("%w: empty file", ErrMissingColumn)
+	case err != nil:
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	idx, err := columnIndexes(header, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	im := &importer{
+		svc:     svc,
+		opt:     opt,
+		report:  &Report{DryRun: opt.DryRun},
+		pending: make([]*pendingRow, 0, batchSize),
+	}
+
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return im.report, fmt.Errorf("failed to read csv row %d: %w", row, err)
+		}
+
+		// Rejected rows wait with the accepted ones so rows are reported in file order
+		res, pr := im.check(row, record, idx)
+		im.pending = append(im.pending, &pendingRow{res: res, pr: pr})
+
+		if len(im.pending) == batchSize {
+			if err := im.flush(ctx); err != nil {
+				return im.report, err
+			}
+		}
+	}
+
+	if err := im.flush(ctx); err != nil {
+		return im.report, err
+	}
+
+	return im.report, nil
+}
+
+type columnIndex struct {
+	country, phone, custId int
+}
+
+func columnIndexes(header []string, columns *Columns) (*columnIndex, error) {
+	idx := &columnIndex{country: -1, phone: -1, custId: -1}
+	for i, name := range header {
+		// Excel writes a byte order mark at the start of the file
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		switch {
+		case strings.EqualFold(name, columns.Country):
+			idx.country = i
+		case strings.EqualFold(name, columns.Phone):
+			idx.phone = i
+		case strings.EqualFold(name, columns.CustId):
+			idx.custId = i
+		}
+	}
+	if idx.phone == -1 {
+		return nil, fmt.Errorf("%w: %q", ErrMissingColumn, columns.Phone)
+	}
+	return idx, nil
+}
+
+type pendingRow struct {
+	res *RowResult
+	pr  *phonebook_v1.PhoneRecord // nil when the row is rejected
+}
+
+type importer struct {
+	svc     phonebook_v1.PhoneBookService
+	opt     *Options
+	report  *Report
+	pending []*pendingRow
+}
+
+func field(record []string, i int) string {
+	if i < 0 || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+// check reads a row, the returned record is nil when the row is rejected
+func (im *importer) check(row int, record []string, idx *columnIndex) (*RowResult, *phonebook_v1.PhoneRecord) {
+	pr := &phonebook_v1.PhoneRecord{
+		CountryName: field(record, idx.country),
+		Number:      field(record, idx.phone),
+		CustId:      field(record, idx.custId),
+	}
+	res := &RowResult{
+		Row:         row,
+		CountryName: pr.CountryName,
+		Number:      pr.Number,
+	}
+
+	if pr.Number == "" {
+		res.Status = RowRejected
+		res.Error = "missing phone number"
+		return res, nil
+	}
+
+	return res, pr
+}
+
+// flush checks the pending rows with the service and commits the accepted ones, in a dry run they are only reported.
+// Rows are checked the same way in a dry run, so it rejects the rows the import would reject.
+func (im *importer) flush(ctx context.Context) error {
+	if len(im.pending) == 0 {
+		return nil
+	}
+	defer func() {
+		im.pending = im.pending[:0]
+	}()
+
+	err := im.validate(ctx)
+	if err != nil {
+		return err
+	}
+
+	accepted := make([]*pendingRow, 0, len(im.pending))
+	for _, p := range im.pending {
+		if p.pr != nil {
+			accepted = append(accepted, p)
+		}
+	}
+
+	if !im.opt.DryRun && len(accepted) > 0 {
+		prs := make([]*phonebook_v1.PhoneRecord, 0, len(accepted))
+		for _, p := range accepted {
+			prs = append(prs, p.pr)
+		}
+
+		batchRes, err := im.svc.BatchCreatePhoneRecords(ctx, &phonebook_v1.BatchCreatePhoneRecordsRequest{
+			PhoneRecords: prs,
+			BestEffort:   true,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to commit rows %d to %d: %w", accepted[0].res.Row, accepted[len(accepted)-1].res.Row, err)
+		}
+
+		for _, item := range batchRes.Results {
+			res := accepted[item.Index].res
+			if item.Code != "" {
+				res.Status = RowFailed
+				res.Error = item.Error
+				continue
+			}
+			res.Status = RowCreated
+			res.RecordId = item.RecordId
+		}
+	}
+
+	for _, p := range im.pending {
+		im.done(p.res)
+	}
+
+	return nil
+}
+
+// validate checks the pending rows the way the service checks records it creates, rejected rows lose their record
+func (im *importer) validate(ctx context.Context) error {
+	checked := make([]*pendingRow, 0, len(im.pending))
+	prs := make([]*phonebook_v1.PhoneRecord, 0, len(im.pending))
+	for _, p := range im.pending {
+		if p.pr != nil {
+			checked = append(checked, p)
+			prs = append(prs, p.pr)
+		}
+	}
+	if len(checked) == 0 {
+		return nil
+	}
+
+	batchRes, err := im.svc.BatchCreatePhoneRecords(ctx, &phonebook_v1.BatchCreatePhoneRecordsRequest{
+		PhoneRecords: prs,
+		BestEffort:   true,
+		ValidateOnly: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to check rows %d to %d: %w", checked[0].res.Row, checked[len(checked)-1].res.Row, err)
+	}
+
+	for _, item := range batchRes.Results {
+		p := checked[item.Index]
+		if item.Code != "" {
+			p.res.Status = RowRejected
+			p.res.Error = item.Error
+			p.pr = nil
+			continue
+		}
+
+		// Detected countries are reported, records are still sent as read so the service detects them again
+		validated := item.PhoneRecord
+		p.res.CountryName = validated.CountryName
+		p.res.NumberE164 = validated.NumberE164
+		p.res.Valid = validated.PhoneValid
+		if validated.Validation != nil {
+			p.res.Reasons = validated.Validation.Reasons
+		}
+
+		if !p.res.Valid && im.opt.SkipInvalid {
+			p.res.Status = RowRejected
+			p.res.Error = "phone number is not valid"
+			p.pr = nil
+			continue
+		}
+		p.res.Status = RowAccepted
+	}
+
+	return nil
+}
+
+// done counts a row that will not change anymore and hands it to OnRow
+func (im *importer) done(res *RowResult) {
+	im.report.TotalRows++
+	switch res.Status {
+	case RowAccepted:
+		im.report.AcceptedRows++
+	case RowCreated:
+		im.report.AcceptedRows++
+		im.report.CreatedRows++
+	case RowRejected:
+		im.report.RejectedRows++
+	case RowFailed:
+		im.report.AcceptedRows++
+		im.report.FailedRows++
+	}
+	if im.opt.OnRow != nil {
+		im.opt.OnRow(res)
+	}
+}
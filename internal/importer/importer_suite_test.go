@@ -0,0 +1,236 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	app "github.com/gidyon/jumia-exercise/internal/app/v1"
+	"github.com/gidyon/jumia-exercise/internal/models"
+	"github.com/gidyon/jumia-exercise/internal/repository"
+	phonebook_v1 "github.com/gidyon/jumia-exercise/pkg/api/phonebook/v1"
+	"github.com/gidyon/jumia-exercise/pkg/utils/phoneutils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+)
+
+func TestImporter(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Importer Suite")
+}
+
+// fakePhoneBook records batch creates, other methods panic through the nil embedded service
+type fakePhoneBook struct {
+	phonebook_v1.PhoneBookService
+	batches [][]*phonebook_v1.PhoneRecord
+	failAt  string
+}
+
+func (fake *fakePhoneBook) BatchCreatePhoneRecords(
+	ctx context.Context, req *phonebook_v1.BatchCreatePhoneRecordsRequest,
+) (*phonebook_v1.BatchPhoneRecordsResponse, error) {
+	if req.ValidateOnly {
+		return fake.validate(req.PhoneRecords), nil
+	}
+
+	fake.batches = append(fake.batches, req.PhoneRecords)
+	res := &phonebook_v1.BatchPhoneRecordsResponse{}
+	for i, pr := range req.PhoneRecords {
+		item := &phonebook_v1.BatchItemResult{Index: int32(i)}
+		if pr.Number == fake.failAt {
+			item.Code = codes.Internal.String()
+			item.Error = "creating phone record failed"
+		} else {
+			item.RecordId = fmt.Sprint(len(fake.batches)*100 + i)
+		}
+		res.Results = append(res.Results, item)
+	}
+	return res, nil
+}
+
+// validate detects missing countries from the dial code and validates the numbers like the service does
+func (fake *fakePhoneBook) validate(prs []*phonebook_v1.PhoneRecord) *phonebook_v1.BatchPhoneRecordsResponse {
+	res := &phonebook_v1.BatchPhoneRecordsResponse{}
+	for i, pr := range prs {
+		item := &phonebook_v1.BatchItemResult{Index: int32(i)}
+		res.Results = append(res.Results, item)
+
+		countryName := pr.CountryName
+		for _, rule := range phoneutils.DefaultRegistry.Rules() {
+			if countryName == "" && strings.HasPrefix(pr.Number, fmt.Sprintf("+%d", rule.DialCode)) {
+				countryName = rule.CountryName
+			}
+		}
+		if countryName == "" {
+			item.Code = codes.InvalidArgument.String()
+			item.Error = "country could not be detected from phone number"
+			continue
+		}
+
+		validated := &phonebook_v1.PhoneRecord{
+			CountryName: countryName,
+			Number:      pr.Number,
+			NumberE164:  phoneutils.NormalizeE164(pr.Number, countryName),
+			CustId:      pr.CustId,
+		}
+		phoneutils.ValidatePhone(validated)
+		item.PhoneRecord = validated
+	}
+	return res
+}
+
+const csvData = `Country,Phone,Cust_Id
+Uganda,(256) 775069443,c1
+Uganda,(256) 7503O6263,c2
+,+237 697151594,c3
+,+999 123456,c4
+Morocco,,c5
+Mozambique,(258) 847651504,c6
+`
+
+var _ = Describe("Importing phone records from csv", func() {
+	var (
+		ctx  context.Context
+		fake *fakePhoneBook
+		opt  *Options
+		rows []*RowResult
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fake = &fakePhoneBook{}
+		rows = nil
+		opt = &Options{
+			BatchSize: 2,
+			OnRow: func(res *RowResult) {
+				rows = append(rows, res)
+			},
+		}
+	})
+
+	When("The header has no phone column", func() {
+		It("should fail", func() {
+			_, err := Import(ctx, fake, strings.NewReader("country,number\nUganda,775069443\n"), opt)
+			Expect(errors.Is(err, ErrMissingColumn)).To(BeTrue())
+		})
+	})
+
+	When("Doing a dry run", func() {
+		It("should report every row without saving", func() {
+			opt.DryRun = true
+			report, err := Import(ctx, fake, strings.NewReader(csvData), opt)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(fake.batches).To(BeEmpty())
+
+			Expect(report.DryRun).To(BeTrue())
+			Expect(report.TotalRows).To(Equal(6))
+			Expect(report.AcceptedRows).To(Equal(4))
+			Expect(report.RejectedRows).To(Equal(2))
+			Expect(report.CreatedRows).To(BeZero())
+
+			Expect(rows).To(HaveLen(6))
+			for i, row := range rows {
+				Expect(row.Row).To(Equal(i + 1))
+			}
+			Expect(rows[0].Status).To(Equal(RowAccepted))
+			Expect(rows[0].Valid).To(BeTrue())
+			Expect(rows[0].NumberE164).To(Equal("+256775069443"))
+			Expect(rows[1].Valid).To(BeFalse())
+			Expect(rows[1].Reasons).ShouldNot(BeEmpty())
+			Expect(rows[2].CountryName).To(Equal("Cameroon"))
+			Expect(rows[3].Status).To(Equal(RowRejected))
+			Expect(rows[3].Error).To(Equal("country could not be detected from phone number"))
+			Expect(rows[4].Status).To(Equal(RowRejected))
+			Expect(rows[4].Error).To(Equal("missing phone number"))
+		})
+	})
+
+	When("Importing", func() {
+		It("should commit accepted rows in batches", func() {
+			fake.failAt = "(258) 847651504"
+			report, err := Import(ctx, fake, strings.NewReader(csvData), opt)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(fake.batches).To(HaveLen(3))
+			Expect(fake.batches[0][0].CustId).To(Equal("c1"))
+			Expect(fake.batches[1]).To(HaveLen(1))
+
+			Expect(report.CreatedRows).To(Equal(3))
+			Expect(report.FailedRows).To(Equal(1))
+			Expect(rows[0].Status).To(Equal(RowCreated))
+			Expect(rows[0].RecordId).ShouldNot(BeEmpty())
+			Expect(rows[5].Status).To(Equal(RowFailed))
+		})
+
+		It("should reject invalid numbers when asked to", func() {
+			opt.SkipInvalid = true
+			opt.Columns = &Columns{Country: "country", Phone: "PHONE"}
+			report, err := Import(ctx, fake, strings.NewReader(csvData), opt)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(report.RejectedRows).To(Equal(3))
+			Expect(rows[1].Status).To(Equal(RowRejected))
+			Expect(rows[0].RecordId).ShouldNot(BeEmpty())
+		})
+	})
+
+	When("Rows are rejected by the service", func() {
+		var (
+			svc      phonebook_v1.PhoneBookService
+			customer *models.Customer
+		)
+
+		BeforeEach(func() {
+			repo := repository.NewMemory()
+			Expect(repo.CreateCountry(ctx, &models.Country{CountryName: "Kenya", CountryCode: 254, Disabled: true})).To(Succeed())
+			customer = &models.Customer{Name: "Jane"}
+			Expect(repo.CreateCustomer(ctx, customer)).To(Succeed())
+
+			var err error
+			svc, err = app.NewPhoneBookService(ctx, &app.Options{Repository: repo, Logger: &zerolog.Logger{}})
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should give the same verdict in a dry run and an import", func() {
+			data := fmt.Sprintf("country,phone,cust_id\n"+
+				"Uganda,(256) 775069443,%d\n"+
+				"Kenya,(254) 712345678,%d\n"+
+				"Uganda,(256) 704244430,%d\n", customer.ID, customer.ID, customer.ID+100)
+
+			opt.DryRun = true
+			report, err := Import(ctx, svc, strings.NewReader(data), opt)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(report.AcceptedRows).To(Equal(1))
+			Expect(report.RejectedRows).To(Equal(2))
+			dryRows := rows
+
+			rows = nil
+			opt.DryRun = false
+			report, err = Import(ctx, svc, strings.NewReader(data), opt)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(report.CreatedRows).To(Equal(1))
+			Expect(report.RejectedRows).To(Equal(2))
+			Expect(report.FailedRows).To(BeZero())
+
+			Expect(rows[0].Status).To(Equal(RowCreated))
+			for i := 1; i < len(rows); i++ {
+				Expect(rows[i].Status).To(Equal(RowRejected))
+				Expect(rows[i].Error).To(Equal(dryRows[i].Error))
+			}
+			Expect(rows[1].Error).To(Equal(`country "Kenya" is disabled`))
+			Expect(rows[2].Error).To(Equal(fmt.Sprintf("customer %q does not exist", fmt.Sprint(customer.ID+100))))
+		})
+	})
+
+	When("The csv is malformed", func() {
+		It("should keep committed batches and fail", func() {
+			data := "country,phone\nUganda,(256) 775069443\nUganda,(256) 704244430\nUganda,\"(256\n"
+			report, err := Import(ctx, fake, strings.NewReader(data), opt)
+			Expect(err).Should(HaveOccurred())
+			Expect(report.CreatedRows).To(Equal(2))
+		})
+	})
+})
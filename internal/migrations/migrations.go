@@ -0,0 +1,194 @@
+// Package migrations applies versioned changes to the database schema, applied versions are recorded in the schema_migrations table
+package migrations
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Migration is a versioned schema change, Down undoes Up
+type Migration struct {
+	Version uint
+	Name    string
+	Up      func(*gorm.DB) error
+	Down    func(*gorm.DB) error
+}
+
+// Status tells whether a migration is applied to the database
+type Status struct {
+	Version   uint
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// ErrNotMigrated is returned by Check when the database is missing migrations
+var ErrNotMigrated = errors.New("database schema is not migrated")
+
+// schemaMigration records an applied migration
+type schemaMigration struct {
+	Version   uint      `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"type:varchar(64)"`
+	AppliedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (*schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// All returns the migrations in the order they are applied
+func All() []*Migration {
+	return append([]*Migration(nil), versions...)
+}
+
+// Latest is the version of the schema the application expects
+func Latest() uint {
+	return versions[len(versions)-1].Version
+}
+
+// Up applies pending migrations up to and including version to, zero applies all of them.
+// Each migration is applied in a transaction with its record.
+func Up(db *gorm.DB, to uint) ([]*Migration, error) {
+	if to == 0 {
+		to = Latest()
+	}
+
+	applied, err := appliedVersions(db)
+	if err != nil {
+		return nil, err
+	}
+
+	done := make([]*Migration, 0, len(versions))
+	for _, m := range versions {
+		if m.Version > to {
+			break
+		}
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := m.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{Version: m.Version, Name: m.Name}).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %d %s failed: %w", m.Version, m.Name, err)
+		}
+		done = append(done, m)
+	}
+
+	return done, nil
+}
+
+// Down undoes the last steps applied migrations, newest first
+func Down(db *gorm.DB, steps int) ([]*Migration, error) {
+	applied, err := appliedVersions(db)
+	if err != nil {
+		return nil, err
+	}
+
+	done := make([]*Migration, 0, steps)
+	for i := len(versions) - 1; i >= 0 && len(done) < steps; i-- {
+		m := versions[i]
+		if _, ok := applied[m.Version]; !ok {
+			continue
+		}
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := m.Down(tx); err != nil {
+				return err
+			}
+			return tx.Delete(&schemaMigration{}, "version = ?", m.Version).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("undoing migration %d %s failed: %w", m.Version, m.Name, err)
+		}
+		done = append(done, m)
+	}
+
+	return done, nil
+}
+
+// List returns the status of every migration
+func List(db *gorm.DB) ([]*Status, error) {
+	applied, err := appliedVersions(db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*Status, 0, len(versions))
+	for _, m := range versions {
+		status := &Status{Version: m.Version, Name: m.Name}
+		if record, ok := applied[m.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = record.AppliedAt
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// Check makes sure every migration is applied and none is unknown to this build
+func Check(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&schemaMigration{}) {
+		return fmt.Errorf("%w, no migration is applied", ErrNotMigrated)
+	}
+
+	records := make([]*schemaMigration, 0, len(versions))
+	err := db.Find(&records).Error
+	if err != nil {
+		return fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+
+	known := make(map[uint]struct{}, len(versions))
+	for _, m := range versions {
+		known[m.Version] = struct{}{}
+	}
+	for _, record := range records {
+		if _, ok := known[record.Version]; !ok {
+			return fmt.Errorf("database has migration %d %s which this build does not know", record.Version, record.Name)
+		}
+	}
+
+	if pending := len(versions) - len(records); pending > 0 {
+		return fmt.Errorf("%w, %d of %d migrations are pending", ErrNotMigrated, pending, len(versions))
+	}
+
+	return nil
+}
+
+// appliedVersions reads the applied migrations, creating the table recording them if needed
+func appliedVersions(db *gorm.DB) (map[uint]*schemaMigration, error) {
+	if !db.Migrator().HasTable(&schemaMigration{}) {
+		err := db.AutoMigrate(&schemaMigration{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create schema_migrations table: %w", err)
+		}
+	}
+
+	records := make([]*schemaMigration, 0, len(versions))
+	err := db.Find(&records).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+
+	applied := make(map[uint]*schemaMigration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+
+	return applied, nil
+}
+
+func init() {
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].Version < versions[j].Version
+	})
+}
@@ -0,0 +1,473 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gidyon/jumia-exercise/internal/database"
+	"github.com/gidyon/jumia-exercise/internal/models"
+	"github.com/gidyon/jumia-exercise/internal/repository"
+	"github.com/gidyon/jumia-exercise/internal/testutil"
+	"github.com/gidyon/jumia-exercise/pkg/utils/phoneutils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestMigrations(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Migrations Suite")
+}
+
+var (
+	// backend is the database of the running spec
+	backend   *testutil.Backend
+	databases int
+	opened    = make(map[string]*gorm.DB)
+)
+
+// forEachBackend describes the specs once for every test database
+func forEachBackend(text string, body func()) bool {
+	for _, b := range testutil.Backends(":memory:") {
+		b := b
+		Describe(fmt.Sprintf("%s on %s", text, b.Driver), func() {
+			BeforeEach(func() {
+				backend = b
+			})
+			body()
+		})
+	}
+	return true
+}
+
+// newDB opens an empty database, a new in memory one for sqlite and the emptied test database otherwise
+func newDB() *gorm.DB {
+	config := &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)}
+
+	if backend.Driver == database.DriverSQLite {
+		databases++
+		db, err := database.Open(backend.Driver, fmt.Sprintf("file:migrations_%d?mode=memory&cache=shared", databases), config)
+		Expect(err).ShouldNot(HaveOccurred())
+		return db
+	}
+
+	db, ok := opened[backend.Driver]
+	if !ok {
+		var err error
+		db, err = database.Open(backend.Driver, backend.DSN, config)
+		Expect(err).ShouldNot(HaveOccurred())
+		opened[backend.Driver] = db
+	}
+	Expect(db.Migrator().DropTable("phones", "customers", "countries", "schema_migrations")).To(Succeed())
+	return db
+}
+
+// expectModelSchema checks the database has every column and index of the models
+func expectModelSchema(db *gorm.DB) {
+	for _, model := range []interface{}{&models.Phone{}, &models.Country{}, &models.Customer{}} {
+		stmt := &gorm.Statement{DB: db}
+		Expect(stmt.Parse(model)).To(Succeed())
+
+		for _, column := range stmt.Schema.DBNames {
+			Expect(db.Migrator().HasColumn(model, column)).To(BeTrue(), "%s.%s", stmt.Table, column)
+		}
+		for name := range stmt.Schema.ParseIndexes() {
+			Expect(db.Migrator().HasIndex(model, name)).To(BeTrue(), "%s %s", stmt.Table, name)
+		}
+	}
+	Expect(db.Migrator().HasConstraint(&models.Phone{}, "Country")).To(BeTrue())
+	Expect(db.Migrator().HasConstraint(&models.Customer{}, "Phones")).To(BeTrue())
+}
+
+// legacyCountry and legacyPhone are the models of the first release, which created its tables without recording
+// migrations. The dial code and validity use portable types instead of the sqlite int(3) and tinyint(1) it declared.
+type legacyCountry struct {
+	ID          uint   `gorm:"primaryKey;autoIncrement"`
+	CountryCode uint   `gorm:"size:16"`
+	CountryName string `gorm:"type:varchar(40)"`
+}
+
+func (*legacyCountry) TableName() string {
+	return "countries"
+}
+
+type legacyPhone struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement"`
+	CountryCode uint      `gorm:"size:16"`
+	CountryName string    `gorm:"type:varchar(40)"`
+	Number      string    `gorm:"index;type:varchar(20);"`
+	CustId      string    `gorm:"index;type:varchar(32);"`
+	PhoneValid  bool      `gorm:"index"`
+	CreateDate  time.Time `gorm:"index;autoCreateTime"`
+}
+
+func (*legacyPhone) TableName() string {
+	return "phones"
+}
+
+var _ = forEachBackend("Schema migrations", func() {
+
+	Context("Migrating an empty database", func() {
+		It("should apply every migration once", func() {
+			db := newDB()
+			Expect(errors.Is(Check(db), ErrNotMigrated)).To(BeTrue())
+
+			applied, err := Up(db, 0)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(applied).To(HaveLen(len(All())))
+			Expect(Check(db)).To(Succeed())
+			expectModelSchema(db)
+
+			applied, err = Up(db, 0)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(applied).To(BeEmpty())
+
+			statuses, err := List(db)
+			Expect(err).ShouldNot(HaveOccurred())
+			for _, status := range statuses {
+				Expect(status.Applied).To(BeTrue())
+				Expect(status.AppliedAt).NotTo(BeZero())
+			}
+		})
+
+		It("should stop at the given version", func() {
+			db := newDB()
+
+			applied, err := Up(db, 2)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(applied).To(HaveLen(2))
+			Expect(errors.Is(Check(db), ErrNotMigrated)).To(BeTrue())
+
+			statuses, err := List(db)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(statuses[1].Applied).To(BeTrue())
+			Expect(statuses[2].Applied).To(BeFalse())
+		})
+	})
+
+	Context("Migrating phones that store their country", func() {
+		var (
+			db      *gorm.DB
+			records []*phoneV1
+		)
+
+		BeforeEach(func() {
+			db = newDB()
+			_, err := Up(db, 1)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			records = []*phoneV1{
+				{Country: phoneCountryV1{CountryCode: 237, CountryName: "Cameroon"}, Number: "(237) 697151594"},
+				{Country: phoneCountryV1{CountryCode: 256, CountryName: "Uganda"}, Number: "(256) 704123456"},
+				{Country: phoneCountryV1{CountryCode: 999, CountryName: "Atlantis"}, Number: "(999) 123456"},
+			}
+			Expect(db.Create(records).Error).To(Succeed())
+
+			// Deleted records are migrated too
+			_, err = Up(db, 5)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(db.Exec("UPDATE phones SET deleted_at = ? WHERE id = ?", time.Now(), records[1].ID).Error).To(Succeed())
+
+			_, err = Up(db, 0)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should fill the new columns and point every record at its country row", func() {
+			expectModelSchema(db)
+
+			phones := make([]*models.Phone, 0, len(records))
+			Expect(db.Unscoped().Preload("Country").Order("id").Find(&phones).Error).To(Succeed())
+			Expect(phones).To(HaveLen(len(records)))
+
+			for i, phone := range phones {
+				Expect(phone.Country.CountryName).To(Equal(records[i].Country.CountryName))
+				Expect(phone.Country.CountryCode).To(Equal(records[i].Country.CountryCode))
+
+				keys := phoneutils.NumberSearchKeys(records[i].Number, records[i].Country.CountryName)
+				Expect(phone.NationalNumber).To(Equal(keys.National))
+				Expect(phone.NumberReversed).To(Equal(keys.Reversed))
+
+				res := phoneutils.Validate(records[i].Number, records[i].Country.CountryName)
+				Expect(phone.NumberE164).To(Equal(phoneutils.NormalizeE164(records[i].Number, records[i].Country.CountryName)))
+				Expect(phone.PhoneValid).To(Equal(res.Valid))
+				Expect(phone.RuleVersion).To(Equal(res.RuleVersion))
+				Expect(phone.UpdateDate).To(BeNil())
+			}
+			Expect(phones[1].DeletedAt.Valid).To(BeTrue())
+
+			// Countries with rules get them, unknown countries only keep their name and dial code
+			Expect(phones[0].Country.Patterns).NotTo(BeEmpty())
+			Expect(phones[2].Country.Patterns).To(BeEmpty())
+		})
+
+		It("should undo the migrations and apply them again", func() {
+			undone, err := Down(db, len(All())-1)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(undone).To(HaveLen(len(All()) - 1))
+			Expect(undone[0].Version).To(Equal(Latest()))
+
+			Expect(db.Migrator().HasTable(&customerV7{})).To(BeFalse())
+			Expect(db.Migrator().HasColumn(&phoneV10{}, "CustomerID")).To(BeFalse())
+			Expect(db.Migrator().HasIndex(&phoneV1{}, "CustId")).To(BeTrue())
+			Expect(db.Migrator().HasColumn(&countryV9{}, "Disabled")).To(BeFalse())
+			Expect(db.Migrator().HasColumn(&phoneV8{}, "CountryID")).To(BeFalse())
+			Expect(db.Migrator().HasColumn(&phoneV6{}, "NationalNumber")).To(BeFalse())
+			Expect(db.Migrator().HasColumn(&phoneV6{}, "NumberReversed")).To(BeFalse())
+			Expect(db.Migrator().HasColumn(&phoneV5{}, "DeletedAt")).To(BeFalse())
+			Expect(db.Migrator().HasColumn(&phoneV4{}, "UpdateDate")).To(BeFalse())
+			Expect(db.Migrator().HasColumn(&phoneV3{}, "NumberE164")).To(BeFalse())
+			Expect(db.Migrator().HasColumn(&countryV2{}, "Patterns")).To(BeFalse())
+			Expect(db.Migrator().HasIndex(&phoneV1{}, "Number")).To(BeTrue())
+
+			phones := make([]*phoneV1, 0, len(records))
+			Expect(db.Order("id").Find(&phones).Error).To(Succeed())
+			Expect(phones).To(HaveLen(len(records)))
+			for i, phone := range phones {
+				Expect(phone.Country).To(Equal(records[i].Country))
+			}
+
+			_, err = Up(db, 0)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(Check(db)).To(Succeed())
+			expectModelSchema(db)
+		})
+	})
+
+	Context("Migrating phones with missing country columns", func() {
+		var db *gorm.DB
+
+		BeforeEach(func() {
+			db = newDB()
+			_, err := Up(db, 1)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should give a country to phones without a dial code", func() {
+			Expect(db.Exec("INSERT INTO phones (country_name, number) VALUES (?, ?)", "Cameroon", "(237) 697151594").Error).To(Succeed())
+
+			_, err := Up(db, 0)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			phone := &models.Phone{}
+			Expect(db.Preload("Country").First(phone).Error).To(Succeed())
+			Expect(phone.CountryID).NotTo(BeZero())
+			Expect(phone.Country.CountryName).To(Equal("Cameroon"))
+		})
+
+		It("should fail naming the phones without a country name", func() {
+			record := &phoneV1{Country: phoneCountryV1{CountryCode: 237, CountryName: "Cameroon"}, Number: "(237) 697151594"}
+			Expect(db.Create(record).Error).To(Succeed())
+			Expect(db.Exec("INSERT INTO phones (country_code, number) VALUES (?, ?)", 256, "(256) 704123456").Error).To(Succeed())
+
+			var id uint
+			Expect(db.Table("phones").Where("country_name IS NULL").Pluck("id", &id).Error).To(Succeed())
+
+			_, err := Up(db, 0)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("phones [%d] have no country name", id)))
+		})
+	})
+
+	Context("Migrating phones that store their customer id", func() {
+		var (
+			db       *gorm.DB
+			customer *customerV7
+			ids      []uint
+		)
+
+		BeforeEach(func() {
+			db = newDB()
+			_, err := Up(db, 9)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			country := &countryV2{CountryName: "Cameroon", CountryCode: 237}
+			Expect(db.Create(country).Error).To(Succeed())
+			customer = &customerV7{Name: "Jane"}
+			Expect(db.Create(customer).Error).To(Succeed())
+
+			ids = nil
+			for _, custId := range []string{fmt.Sprint(customer.ID), "legacy-7", ""} {
+				Expect(db.Exec(
+					"INSERT INTO phones (country_id, number, cust_id) VALUES (?, ?, ?)", country.ID, "(237) 697151594", custId,
+				).Error).To(Succeed())
+			}
+			Expect(db.Table("phones").Order("id").Pluck("id", &ids).Error).To(Succeed())
+
+			_, err = Up(db, 0)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should point records at their customer and keep customers with records", func() {
+			expectModelSchema(db)
+
+			phones := make([]*models.Phone, 0, len(ids))
+			Expect(db.Order("id").Find(&phones).Error).To(Succeed())
+			Expect(phones).To(HaveLen(3))
+			Expect(*phones[0].CustomerID).To(Equal(customer.ID))
+			Expect(phones[2].CustomerID).To(BeNil())
+
+			// Ids from before customers were stored get a customer of their own
+			legacy := &models.Customer{}
+			Expect(db.First(legacy, *phones[1].CustomerID).Error).To(Succeed())
+			Expect(legacy.Name).To(Equal("legacy-7"))
+			Expect(legacy.Metadata).To(MatchJSON(`{"cust_id": "legacy-7"}`))
+
+			Expect(db.Delete(&models.Customer{}, customer.ID).Error).Should(HaveOccurred())
+			Expect(db.Create(&models.Phone{CountryID: phones[0].CountryID, CustomerID: &[]uint{legacy.ID + 100}[0]}).Error).Should(HaveOccurred())
+		})
+
+		It("should store the customer ids again when undone", func() {
+			_, err := Down(db, int(Latest())-9)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(db.Migrator().HasColumn(&phoneV10{}, "CustomerID")).To(BeFalse())
+
+			custIds := make([]string, 0, len(ids))
+			Expect(db.Table("phones").Order("id").Pluck("cust_id", &custIds).Error).To(Succeed())
+			Expect(custIds[0]).To(Equal(fmt.Sprint(customer.ID)))
+			Expect(custIds[1]).NotTo(BeEmpty())
+			Expect(custIds[2]).To(BeEmpty())
+
+			Expect(db.Delete(&customerV7{}, customer.ID).Error).To(Succeed())
+		})
+	})
+
+	Context("Migrating phones created with an update date", func() {
+		It("should only keep the update date of updated records", func() {
+			db := newDB()
+			_, err := Up(db, 10)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			country := &countryV2{CountryName: "Cameroon", CountryCode: 237}
+			Expect(db.Create(country).Error).To(Succeed())
+			created := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+			for _, updated := range []time.Time{created, created.Add(time.Minute)} {
+				Expect(db.Exec(
+					"INSERT INTO phones (country_id, number, create_date, update_date) VALUES (?, ?, ?, ?)",
+					country.ID, "(237) 697151594", created, updated,
+				).Error).To(Succeed())
+			}
+
+			_, err = Up(db, 0)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			phones := make([]*models.Phone, 0, 2)
+			Expect(db.Order("id").Find(&phones).Error).To(Succeed())
+			Expect(phones[0].UpdateDate).To(BeNil())
+			Expect(phones[1].UpdateDate).NotTo(BeNil())
+			Expect(phones[1].UpdateDate.After(phones[1].CreateDate)).To(BeTrue())
+		})
+	})
+
+	Context("Migrating countries whose names differ in case", func() {
+		It("should merge them into the oldest and keep names unique", func() {
+			db := newDB()
+			_, err := Up(db, 11)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			countries := []*countryV2{
+				{CountryName: "Cameroon", CountryCode: 237},
+				{CountryName: "CAMEROON", CountryCode: 237},
+				{CountryName: "Uganda", CountryCode: 256},
+			}
+			Expect(db.Create(countries).Error).To(Succeed())
+			for _, country := range countries {
+				Expect(db.Exec(
+					"INSERT INTO phones (country_id, number, create_date) VALUES (?, ?, ?)", country.ID, "697151594", time.Now(),
+				).Error).To(Succeed())
+			}
+
+			_, err = Up(db, 0)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			ids := make([]uint, 0, 3)
+			Expect(db.Table("phones").Order("id").Pluck("country_id", &ids).Error).To(Succeed())
+			Expect(ids).To(Equal([]uint{countries[0].ID, countries[0].ID, countries[2].ID}))
+			var count int64
+			Expect(db.Table("countries").Count(&count).Error).To(Succeed())
+			Expect(count).To(BeEquivalentTo(2))
+
+			err = db.Create(&countryV2{CountryName: "cameroon", CountryCode: 237}).Error
+			Expect(database.IsUniqueViolation(err)).To(BeTrue())
+
+			_, err = Down(db, 1)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(db.Migrator().HasIndex(&countryV9{}, countryNameIndex)).To(BeFalse())
+			Expect(db.Create(&countryV2{CountryName: "cameroon", CountryCode: 237}).Error).To(Succeed())
+		})
+	})
+
+	Context("Migrating a database created before versioned migrations", func() {
+		It("should apply every migration to the tables of the first release", func() {
+			db := newDB()
+			Expect(db.AutoMigrate(&legacyCountry{}, &legacyPhone{})).To(Succeed())
+
+			country := &legacyCountry{CountryName: "Cameroon", CountryCode: 237}
+			Expect(db.Create(country).Error).To(Succeed())
+			phone := &legacyPhone{CountryName: "Cameroon", CountryCode: 237, Number: "(237) 697151594", CustId: "legacy-7"}
+			Expect(db.Create(phone).Error).To(Succeed())
+
+			applied, err := Up(db, 0)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(applied).To(HaveLen(len(All())))
+			Expect(Check(db)).To(Succeed())
+			expectModelSchema(db)
+
+			ctx := context.Background()
+			repo := repository.NewGorm(db)
+
+			got, err := repo.GetPhone(ctx, phone.ID)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(got.Country.ID).To(Equal(country.ID))
+			Expect(got.Country.Patterns).NotTo(BeEmpty())
+			Expect(got.NumberE164).To(Equal("+237697151594"))
+			Expect(got.NationalNumber).To(Equal("697151594"))
+			Expect(got.PhoneValid).To(BeTrue())
+			Expect(got.CustomerID).NotTo(BeNil())
+			Expect(got.UpdateDate).To(BeNil())
+
+			customer, err := repo.GetCustomer(ctx, *got.CustomerID)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(customer.Name).To(Equal("legacy-7"))
+
+			created := &models.Phone{CountryID: country.ID, Number: "(237) 677123456"}
+			Expect(repo.CreatePhone(ctx, created)).To(Succeed())
+
+			Expect(repo.UpdatePhone(ctx, phone.ID, func(p *models.Phone) error {
+				p.Number = "(237) 697151595"
+				return nil
+			})).To(Succeed())
+			got, err = repo.GetPhone(ctx, phone.ID)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(got.Number).To(Equal("(237) 697151595"))
+			Expect(got.UpdateDate).NotTo(BeNil())
+
+			moved, err := repo.DeletePhones(ctx, []uint{phone.ID}, "tester", false)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(moved).To(ConsistOf(phone.ID))
+			_, err = repo.GetPhone(ctx, phone.ID)
+			Expect(errors.Is(err, repository.ErrNotFound)).To(BeTrue())
+
+			phones, err := repo.ListPhones(ctx, &repository.PhoneQuery{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(phones).To(HaveLen(1))
+			Expect(phones[0].ID).To(Equal(created.ID))
+		})
+	})
+
+	Context("Checking a database migrated by a newer build", func() {
+		It("should fail on the unknown migration", func() {
+			db := newDB()
+			_, err := Up(db, 0)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(db.Create(&schemaMigration{Version: Latest() + 1, Name: "from_the_future"}).Error).To(Succeed())
+
+			err = Check(db)
+			Expect(err).Should(HaveOccurred())
+			Expect(errors.Is(err, ErrNotMigrated)).To(BeFalse())
+		})
+	})
+})
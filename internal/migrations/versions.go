@@ -0,0 +1,774 @@
+package migrations
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gidyon/jumia-exercise/internal/models"
+	phonebook_v1 "github.com/gidyon/jumia-exercise/pkg/api/phonebook/v1"
+	"github.com/gidyon/jumia-exercise/pkg/utils/phoneutils"
+	"gorm.io/gorm"
+)
+
+// versions are the schema changes in the order they are applied. Version 1 is the schema of the first release, which
+// created its tables without recording migrations, so existing tables are kept and every later change is applied to them.
+// Databases created by builds that migrated the models directly already have some of the changes, the migrations skip those.
+// Migrations use their own copies of the tables, later changes to the models must not change what an old migration does.
+var versions = []*Migration{
+	{
+		Version: 1,
+		Name:    "create_phones_and_countries",
+		Up: func(tx *gorm.DB) error {
+			for _, table := range []interface{}{&countryV1{}, &phoneV1{}} {
+				if tx.Migrator().HasTable(table) {
+					continue
+				}
+				err := tx.Migrator().CreateTable(table)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&phoneV1{}, &countryV1{})
+		},
+	},
+	{
+		Version: 2,
+		Name:    "add_country_rules",
+		Up: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&countryV2{}, "Patterns") {
+				return nil
+			}
+			err := addColumns(tx, &countryV2{}, countryV2RuleFields...)
+			if err != nil {
+				return err
+			}
+			return backfillCountryRules(tx)
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropColumns(tx, &countryV2{}, countryV2RuleFields...)
+		},
+	},
+	{
+		Version: 3,
+		Name:    "add_phone_validation_results",
+		Up: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&phoneV3{}, "NumberE164") {
+				return nil
+			}
+			err := addColumns(tx, &phoneV3{}, phoneV3ResultFields...)
+			if err != nil {
+				return err
+			}
+			return backfillValidationResults(tx)
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropColumns(tx, &phoneV3{}, phoneV3ResultFields...)
+		},
+	},
+	{
+		Version: 4,
+		Name:    "add_phone_update_date",
+		// Records of the first release were never updated, they keep an empty update date
+		Up: func(tx *gorm.DB) error {
+			return addColumns(tx, &phoneV4{}, "UpdateDate")
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropColumns(tx, &phoneV4{}, "UpdateDate")
+		},
+	},
+	{
+		Version: 5,
+		Name:    "add_phone_soft_delete",
+		Up: func(tx *gorm.DB) error {
+			return addColumns(tx, &phoneV5{}, "DeletedAt", "DeletedBy")
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropColumns(tx, &phoneV5{}, "DeletedAt", "DeletedBy")
+		},
+	},
+	{
+		Version: 6,
+		Name:    "add_phone_search_keys",
+		Up: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&phoneV6{}, "NumberReversed") {
+				return nil
+			}
+			err := addColumns(tx, &phoneV6{}, "NationalNumber", "NumberReversed")
+			if err != nil {
+				return err
+			}
+			return backfillSearchKeys(tx)
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropColumns(tx, &phoneV6{}, "NationalNumber", "NumberReversed")
+		},
+	},
+	{
+		Version: 7,
+		Name:    "create_customers",
+		Up: func(tx *gorm.DB) error {
+			if tx.Migrator().HasTable(&customerV7{}) {
+				return nil
+			}
+			return tx.Migrator().CreateTable(&customerV7{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&customerV7{})
+		},
+	},
+	{
+		Version: 8,
+		Name:    "reference_countries_from_phones",
+		Up: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&phoneV8{}, "CountryID") {
+				return nil
+			}
+			return referenceCountries(tx)
+		},
+		Down: func(tx *gorm.DB) error {
+			return embedCountries(tx)
+		},
+	},
+	{
+		Version: 9,
+		Name:    "add_country_disabled",
+		Up: func(tx *gorm.DB) error {
+			return addColumns(tx, &countryV9{}, "Disabled")
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropColumns(tx, &countryV9{}, "Disabled")
+		},
+	},
+	{
+		Version: 10,
+		Name:    "reference_customers_from_phones",
+		Up: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&phoneV10{}, "CustomerID") {
+				return nil
+			}
+			return referenceCustomers(tx)
+		},
+		Down: func(tx *gorm.DB) error {
+			return embedCustomerIds(tx)
+		},
+	},
+	{
+		Version: 11,
+		Name:    "clear_update_date_of_new_phones",
+		// Phones were given an update date when they were created, records that kept it were never updated
+		Up: func(tx *gorm.DB) error {
+			return tx.Exec("UPDATE phones SET update_date = NULL WHERE update_date = create_date").Error
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Exec("UPDATE phones SET update_date = create_date WHERE update_date IS NULL").Error
+		},
+	},
+	{
+		Version: 12,
+		Name:    "unique_country_names",
+		// Merged countries are not split again when undone
+		Up: func(tx *gorm.DB) error {
+			if tx.Migrator().HasIndex(&countryV9{}, countryNameIndex) {
+				return nil
+			}
+			return uniqueCountryNames(tx)
+		},
+		Down: func(tx *gorm.DB) error {
+			if !tx.Migrator().HasIndex(&countryV9{}, countryNameIndex) {
+				return nil
+			}
+			return tx.Migrator().DropIndex(&countryV9{}, countryNameIndex)
+		},
+	},
+}
+
+const backfillBatchSize = 500
+
+// backfillCountryRules gives the built in rules to countries of the first release, which only stored a name and dial code
+func backfillCountryRules(tx *gorm.DB) error {
+	countries := make([]*countryV2, 0, 10)
+	err := tx.Find(&countries).Error
+	if err != nil {
+		return err
+	}
+
+	for _, country := range countries {
+		rule, ok := phoneutils.DefaultRegistry.Rule(country.CountryName)
+		if !ok {
+			continue
+		}
+		withRule := countryV2FromRule(rule)
+		err = tx.Model(country).Select(countryV2RuleFields).Updates(withRule).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// backfillValidationResults validates phones of the first release, which only stored whether the number was valid
+func backfillValidationResults(tx *gorm.DB) error {
+	rows := make([]*phoneV1, 0, backfillBatchSize)
+	return tx.FindInBatches(&rows, backfillBatchSize, func(*gorm.DB, int) error {
+		for _, row := range rows {
+			pr := &phonebook_v1.PhoneRecord{
+				CountryName: row.Country.CountryName,
+				CountryCode: row.Country.CountryCode,
+				Number:      row.Number,
+			}
+			phoneutils.ValidatePhone(pr)
+
+			err := tx.Table("phones").Where("id = ?", row.ID).UpdateColumns(map[string]interface{}{
+				"number_e164":        phoneutils.NormalizeE164(row.Number, row.Country.CountryName),
+				"number_type":        pr.NumberType,
+				"operator":           pr.Operator,
+				"phone_valid":        pr.PhoneValid,
+				"validation_reasons": strings.Join(pr.Validation.Reasons, ","),
+				"rule_version":       pr.Validation.RuleVersion,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
+
+// backfillSearchKeys fills the search columns of phones stored before number search
+func backfillSearchKeys(tx *gorm.DB) error {
+	rows := make([]*phoneV1, 0, backfillBatchSize)
+	return tx.FindInBatches(&rows, backfillBatchSize, func(*gorm.DB, int) error {
+		for _, row := range rows {
+			keys := phoneutils.NumberSearchKeys(row.Number, row.Country.CountryName)
+			err := tx.Table("phones").Where("id = ?", row.ID).UpdateColumns(map[string]interface{}{
+				"national_number": keys.National,
+				"number_reversed": keys.Reversed,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
+
+// referenceCountries points phones at their country rows instead of storing the country name and dial code.
+// Countries without a row get one from the built in rules, unknown countries get a row with only their name and
+// dial code so no record is lost. Records without a country name cannot be given a country and stop the migration.
+func referenceCountries(tx *gorm.DB) error {
+	err := tx.Migrator().AddColumn(&phoneV8{}, "CountryID")
+	if err != nil {
+		return err
+	}
+
+	// Missing values are read and matched as empty so rows with NULL columns get a country too
+	const (
+		nameExpr = "COALESCE(country_name, '')"
+		codeExpr = "COALESCE(country_code, 0)"
+	)
+	embedded := make([]*phoneCountryV1, 0, 10)
+	err = tx.Table("phones").
+		Select(fmt.Sprintf("DISTINCT %s AS country_name, %s AS country_code", nameExpr, codeExpr)).Scan(&embedded).Error
+	if err != nil {
+		return err
+	}
+
+	for _, old := range embedded {
+		// A country cannot be told from a dial code alone, several countries share some
+		if strings.TrimSpace(old.CountryName) == "" {
+			ids := make([]uint, 0, 1)
+			err = tx.Table("phones").Where(nameExpr+" = ?", old.CountryName).Order("id").Pluck("id", &ids).Error
+			if err != nil {
+				return err
+			}
+			return fmt.Errorf("phones %v have no country name, set it before migrating", ids)
+		}
+
+		countries := make([]*countryV2, 0, 1)
+		err = tx.Order("id").Limit(1).Find(&countries, "LOWER(country_name) = ?", strings.ToLower(strings.TrimSpace(old.CountryName))).Error
+		if err != nil {
+			return err
+		}
+
+		var country *countryV2
+		switch rule, ok := phoneutils.DefaultRegistry.Rule(old.CountryName); {
+		case len(countries) != 0:
+			country = countries[0]
+		case ok:
+			country = countryV2FromRule(rule)
+		default:
+			country = &countryV2{CountryName: old.CountryName, CountryCode: old.CountryCode}
+		}
+		if country.ID == 0 {
+			err = tx.Create(country).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		err = tx.Table("phones").
+			Where(nameExpr+" = ? AND "+codeExpr+" = ?", old.CountryName, old.CountryCode).
+			Update("country_id", country.ID).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	err = dropColumns(tx, &phoneV6{}, "CountryCode", "CountryName")
+	if err != nil {
+		return err
+	}
+
+	err = tx.Migrator().CreateConstraint(&phoneV8{}, "Country")
+	if err != nil {
+		return err
+	}
+
+	// The index of the country reference, and the indexes of drivers that rebuild tables to add constraints
+	return createIndexes(tx, &phoneV8{})
+}
+
+// embedCountries stores the country name and dial code alongside each phone again and drops the country reference
+func embedCountries(tx *gorm.DB) error {
+	for _, field := range []string{"CountryCode", "CountryName"} {
+		err := tx.Migrator().AddColumn(&phoneV6{}, field)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := tx.Exec(
+		"UPDATE phones SET " +
+			"country_code = (SELECT country_code FROM countries WHERE countries.id = phones.country_id), " +
+			"country_name = (SELECT country_name FROM countries WHERE countries.id = phones.country_id)",
+	).Error
+	if err != nil {
+		return err
+	}
+
+	if tx.Migrator().HasConstraint(&phoneV8{}, "Country") {
+		err = tx.Migrator().DropConstraint(&phoneV8{}, "Country")
+		if err != nil {
+			return err
+		}
+	}
+	err = dropColumns(tx, &phoneV8{}, "CountryID")
+	if err != nil {
+		return err
+	}
+
+	// Adds back the indexes of drivers that rebuild tables to drop constraints
+	return createIndexes(tx, &phoneV6{})
+}
+
+// referenceCustomers points phones at their customer rows instead of storing a customer id the database does not check.
+// Ids of customer rows are kept, other ids come from before customers were stored and get a row named after them
+// that keeps the id in its metadata.
+func referenceCustomers(tx *gorm.DB) error {
+	err := tx.Migrator().AddColumn(&phoneV10{}, "CustomerID")
+	if err != nil {
+		return err
+	}
+
+	custIds := make([]string, 0, 10)
+	err = tx.Table("phones").Distinct("cust_id").Where("cust_id <> ''").Pluck("cust_id", &custIds).Error
+	if err != nil {
+		return err
+	}
+
+	for _, custId := range custIds {
+		customers := make([]*customerV7, 0, 1)
+		if id, err := strconv.ParseUint(custId, 10, 64); err == nil {
+			err = tx.Limit(1).Find(&customers, "id = ?", id).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		var customer *customerV7
+		if len(customers) != 0 {
+			customer = customers[0]
+		} else {
+			metadata, err := json.Marshal(map[string]string{"cust_id": custId})
+			if err != nil {
+				return err
+			}
+			customer = &customerV7{Name: custId, Metadata: string(metadata)}
+			err = tx.Create(customer).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		err = tx.Table("phones").Where("cust_id = ?", custId).Update("customer_id", customer.ID).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tx.Migrator().CreateConstraint(&customerV10{}, "Phones")
+	if err != nil {
+		return err
+	}
+
+	err = dropColumns(tx, &phoneV8{}, "CustId")
+	if err != nil {
+		return err
+	}
+
+	// The index of the customer reference, and the indexes of drivers that rebuild tables to add constraints
+	return createIndexes(tx, &phoneV10{})
+}
+
+// embedCustomerIds stores the id of their customer alongside each phone again and drops the customer reference
+func embedCustomerIds(tx *gorm.DB) error {
+	err := tx.Migrator().AddColumn(&phoneV8{}, "CustId")
+	if err != nil {
+		return err
+	}
+	err = tx.Exec("UPDATE phones SET cust_id = ''").Error
+	if err != nil {
+		return err
+	}
+
+	customerIds := make([]uint, 0, 10)
+	err = tx.Table("phones").Distinct("customer_id").Where("customer_id IS NOT NULL").Pluck("customer_id", &customerIds).Error
+	if err != nil {
+		return err
+	}
+	for _, id := range customerIds {
+		err = tx.Table("phones").Where("customer_id = ?", id).Update("cust_id", fmt.Sprint(id)).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	if tx.Migrator().HasConstraint(&customerV10{}, "Phones") {
+		err = tx.Migrator().DropConstraint(&customerV10{}, "Phones")
+		if err != nil {
+			return err
+		}
+	}
+	err = dropColumns(tx, &phoneV10{}, "CustomerID")
+	if err != nil {
+		return err
+	}
+
+	// The index of the customer id, and the indexes of drivers that rebuild tables to drop constraints
+	return createIndexes(tx, &phoneV8{})
+}
+
+const countryNameIndex = "idx_countries_country_name"
+
+// uniqueCountryNames merges countries whose names only differ in case into the oldest of them, which is the one phone
+// records were resolved to, then adds the unique index on the name. Names are compared ignoring case, MySQL already
+// does so with its default collation while sqlite and PostgreSQL index the lower case name.
+func uniqueCountryNames(tx *gorm.DB) error {
+	countries := make([]*struct {
+		ID   uint
+		Name string
+	}, 0, 10)
+	err := tx.Table("countries").Select("id, LOWER(country_name) AS name").Order("id").Scan(&countries).Error
+	if err != nil {
+		return err
+	}
+
+	oldest := make(map[string]uint, len(countries))
+	for _, country := range countries {
+		id, ok := oldest[country.Name]
+		if !ok {
+			oldest[country.Name] = country.ID
+			continue
+		}
+		err = tx.Table("phones").Where("country_id = ?", country.ID).Update("country_id", id).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Exec("DELETE FROM countries WHERE id = ?", country.ID).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	column := "LOWER(country_name)"
+	if tx.Dialector.Name() == "mysql" {
+		column = "country_name"
+	}
+	return tx.Exec(fmt.Sprintf("CREATE UNIQUE INDEX %s ON countries (%s)", countryNameIndex, column)).Error
+}
+
+// createIndexes creates the indexes of model the table is missing
+func createIndexes(tx *gorm.DB, model interface{}) error {
+	stmt := &gorm.Statement{DB: tx}
+	err := stmt.Parse(model)
+	if err != nil {
+		return err
+	}
+
+	for name := range stmt.Schema.ParseIndexes() {
+		if tx.Migrator().HasIndex(model, name) {
+			continue
+		}
+		err = tx.Migrator().CreateIndex(model, name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addColumns adds the columns of fields the table is missing, together with the indexes of model
+func addColumns(tx *gorm.DB, model interface{}, fields ...string) error {
+	for _, field := range fields {
+		if tx.Migrator().HasColumn(model, field) {
+			continue
+		}
+		err := tx.Migrator().AddColumn(model, field)
+		if err != nil {
+			return err
+		}
+	}
+
+	return createIndexes(tx, model)
+}
+
+// dropColumns drops columns together with their indexes. The sqlite driver of gorm drops columns by rebuilding the
+// table from its DDL, which loses every index and fails to remove the last column, so sqlite drops them itself.
+func dropColumns(tx *gorm.DB, model interface{}, fields ...string) error {
+	stmt := &gorm.Statement{DB: tx}
+	err := stmt.Parse(model)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		if !tx.Migrator().HasColumn(model, field) {
+			continue
+		}
+		if tx.Migrator().HasIndex(model, field) {
+			err = tx.Migrator().DropIndex(model, field)
+			if err != nil {
+				return err
+			}
+		}
+
+		if tx.Dialector.Name() == "sqlite" {
+			err = tx.Exec("ALTER TABLE " + tx.Statement.Quote(stmt.Table) +
+				" DROP COLUMN " + tx.Statement.Quote(stmt.Schema.LookUpField(field).DBName)).Error
+		} else {
+			err = tx.Migrator().DropColumn(model, field)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// countryV1 is the countries table of the first release
+type countryV1 struct {
+	ID          uint   `gorm:"primaryKey;autoIncrement"`
+	CountryCode uint   `gorm:"size:16"`
+	CountryName string `gorm:"type:varchar(40)"`
+}
+
+func (*countryV1) TableName() string {
+	return "countries"
+}
+
+// countryV2 adds the validation rules of the country
+type countryV2 struct {
+	ID          uint   `gorm:"primaryKey;autoIncrement"`
+	CountryCode uint   `gorm:"size:16"`
+	CountryName string `gorm:"type:varchar(40)"`
+	ISOCode     string `gorm:"type:varchar(2)"`
+	TrunkPrefix string `gorm:"type:varchar(4)"`
+	Patterns    string `gorm:"type:text"`
+	MinLength   int    `gorm:"size:8"`
+	MaxLength   int    `gorm:"size:8"`
+	NumberTypes string `gorm:"type:text"`
+	Operators   string `gorm:"type:text"`
+}
+
+func (*countryV2) TableName() string {
+	return "countries"
+}
+
+var countryV2RuleFields = []string{"ISOCode", "TrunkPrefix", "Patterns", "MinLength", "MaxLength", "NumberTypes", "Operators"}
+
+// countryV2FromRule converts a validation rule to a country row
+func countryV2FromRule(rule *phoneutils.CountryRule) *countryV2 {
+	c := models.CountryFromRule(rule)
+	return &countryV2{
+		CountryCode: c.CountryCode,
+		CountryName: c.CountryName,
+		ISOCode:     c.ISOCode,
+		TrunkPrefix: c.TrunkPrefix,
+		Patterns:    c.Patterns,
+		MinLength:   c.MinLength,
+		MaxLength:   c.MaxLength,
+		NumberTypes: c.NumberTypes,
+		Operators:   c.Operators,
+	}
+}
+
+// countryV9 adds disabling countries
+type countryV9 struct {
+	Country  countryV2 `gorm:"embedded"`
+	Disabled bool      `gorm:"index;default:false"`
+}
+
+func (*countryV9) TableName() string {
+	return "countries"
+}
+
+// phoneCountryV1 is the country stored alongside each phone before phones referenced countries
+type phoneCountryV1 struct {
+	CountryCode uint   `gorm:"size:16"`
+	CountryName string `gorm:"type:varchar(40)"`
+}
+
+// phoneV1 is the phones table of the first release, storing the country alongside each number
+type phoneV1 struct {
+	ID         uint           `gorm:"primaryKey;autoIncrement"`
+	Country    phoneCountryV1 `gorm:"embedded"`
+	Number     string         `gorm:"index;type:varchar(20);"`
+	CustId     string         `gorm:"index;type:varchar(32);"`
+	PhoneValid bool           `gorm:"index"`
+	CreateDate time.Time      `gorm:"index;autoCreateTime"`
+}
+
+func (*phoneV1) TableName() string {
+	return "phones"
+}
+
+// phoneV3 adds the results of validating the number against the country rules
+type phoneV3 struct {
+	Phone             phoneV1 `gorm:"embedded"`
+	NumberE164        string  `gorm:"index;type:varchar(16);"`
+	NumberType        string  `gorm:"index;type:varchar(16);"`
+	Operator          string  `gorm:"index;type:varchar(32);"`
+	ValidationReasons string  `gorm:"type:varchar(128)"`
+	RuleVersion       string  `gorm:"type:varchar(20)"`
+}
+
+func (*phoneV3) TableName() string {
+	return "phones"
+}
+
+var phoneV3ResultFields = []string{"NumberE164", "NumberType", "Operator", "ValidationReasons", "RuleVersion"}
+
+// phoneV4 adds the date of the last update
+type phoneV4 struct {
+	Phone      phoneV3 `gorm:"embedded"`
+	UpdateDate *time.Time
+}
+
+func (*phoneV4) TableName() string {
+	return "phones"
+}
+
+// phoneV5 adds soft deletes
+type phoneV5 struct {
+	Phone     phoneV4        `gorm:"embedded"`
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+	DeletedBy string         `gorm:"type:varchar(32)"`
+}
+
+func (*phoneV5) TableName() string {
+	return "phones"
+}
+
+// phoneV6 adds the columns prefix and suffix number searches run on
+type phoneV6 struct {
+	Phone          phoneV5 `gorm:"embedded"`
+	NationalNumber string  `gorm:"index;type:varchar(20);"`
+	NumberReversed string  `gorm:"index;type:varchar(20);"`
+}
+
+func (*phoneV6) TableName() string {
+	return "phones"
+}
+
+// phoneV8 references the country row instead of storing the country
+type phoneV8 struct {
+	ID                uint       `gorm:"primaryKey;autoIncrement"`
+	CountryID         uint       `gorm:"index"`
+	Country           *countryV2 `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+	Number            string     `gorm:"index;type:varchar(20);"`
+	NumberE164        string     `gorm:"index;type:varchar(16);"`
+	NationalNumber    string     `gorm:"index;type:varchar(20);"`
+	NumberReversed    string     `gorm:"index;type:varchar(20);"`
+	NumberType        string     `gorm:"index;type:varchar(16);"`
+	Operator          string     `gorm:"index;type:varchar(32);"`
+	CustId            string     `gorm:"index;type:varchar(32);"`
+	PhoneValid        bool       `gorm:"index"`
+	ValidationReasons string     `gorm:"type:varchar(128)"`
+	RuleVersion       string     `gorm:"type:varchar(20)"`
+	CreateDate        time.Time  `gorm:"index;autoCreateTime"`
+	UpdateDate        *time.Time
+	DeletedAt         gorm.DeletedAt `gorm:"index"`
+	DeletedBy         string         `gorm:"type:varchar(32)"`
+}
+
+func (*phoneV8) TableName() string {
+	return "phones"
+}
+
+// phoneV10 references the customer row instead of storing the customer id
+type phoneV10 struct {
+	ID                uint       `gorm:"primaryKey;autoIncrement"`
+	CountryID         uint       `gorm:"index"`
+	Country           *countryV2 `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+	CustomerID        *uint      `gorm:"index"`
+	Number            string     `gorm:"index;type:varchar(20);"`
+	NumberE164        string     `gorm:"index;type:varchar(16);"`
+	NationalNumber    string     `gorm:"index;type:varchar(20);"`
+	NumberReversed    string     `gorm:"index;type:varchar(20);"`
+	NumberType        string     `gorm:"index;type:varchar(16);"`
+	Operator          string     `gorm:"index;type:varchar(32);"`
+	PhoneValid        bool       `gorm:"index"`
+	ValidationReasons string     `gorm:"type:varchar(128)"`
+	RuleVersion       string     `gorm:"type:varchar(20)"`
+	CreateDate        time.Time  `gorm:"index;autoCreateTime"`
+	UpdateDate        *time.Time
+	DeletedAt         gorm.DeletedAt `gorm:"index"`
+	DeletedBy         string         `gorm:"type:varchar(32)"`
+}
+
+func (*phoneV10) TableName() string {
+	return "phones"
+}
+
+// customerV7 is the customers table owning phone records
+type customerV7 struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement"`
+	Name       string    `gorm:"type:varchar(64)"`
+	Email      string    `gorm:"index;type:varchar(128)"`
+	Metadata   string    `gorm:"type:text"`
+	CreateDate time.Time `gorm:"autoCreateTime"`
+	UpdateDate time.Time `gorm:"autoUpdateTime"`
+}
+
+func (*customerV7) TableName() string {
+	return "customers"
+}
+
+// customerV10 has the phone records referencing the customer, the database keeps referenced customers from being deleted
+type customerV10 struct {
+	Customer customerV7  `gorm:"embedded"`
+	Phones   []*phoneV10 `gorm:"foreignKey:CustomerID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+}
+
+func (*customerV10) TableName() string {
+	return "customers"
+}
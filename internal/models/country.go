@@ -1,11 +1,83 @@
 package models
 
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gidyon/jumia-exercise/pkg/utils/phoneutils"
+)
+
 type Country struct {
 	ID          uint   `gorm:"primaryKey;autoIncrement"`
-	CountryCode uint   `gorm:"type:int(3)"`
-	CountryName string `gorm:"type:varchar(40)"`
+	CountryCode uint   `gorm:"size:16"`
+	CountryName string `gorm:"type:varchar(40);uniqueIndex:idx_countries_country_name"` // unique ignoring case
+	ISOCode     string `gorm:"type:varchar(2)"`
+	TrunkPrefix string `gorm:"type:varchar(4)"`
+	Patterns    string `gorm:"type:text"` // newline separated regular expressions
+	MinLength   int    `gorm:"size:8"`
+	MaxLength   int    `gorm:"size:8"`
+	NumberTypes string `gorm:"type:text"` // json object of number type to prefixes
+	Operators   string `gorm:"type:text"` // json object of operator to prefixes
+	Disabled    bool   `gorm:"index;default:false"`
 }
 
 func (*Country) TableName() string {
 	return "countries"
 }
+
+// CountryFromRule converts a validation rule to a country row
+func CountryFromRule(rule *phoneutils.CountryRule) *Country {
+	var numberTypes, operators string
+	if len(rule.NumberTypes) != 0 {
+		bs, _ := json.Marshal(rule.NumberTypes)
+		numberTypes = string(bs)
+	}
+	if len(rule.Operators) != 0 {
+		bs, _ := json.Marshal(rule.Operators)
+		operators = string(bs)
+	}
+	return &Country{
+		CountryCode: rule.DialCode,
+		CountryName: rule.CountryName,
+		ISOCode:     rule.ISOCode,
+		TrunkPrefix: rule.TrunkPrefix,
+		Patterns:    strings.Join(rule.Patterns, "\n"),
+		MinLength:   rule.MinLength,
+		MaxLength:   rule.MaxLength,
+		NumberTypes: numberTypes,
+		Operators:   operators,
+	}
+}
+
+// Rule converts the country row to a validation rule
+func (c *Country) Rule() (*phoneutils.CountryRule, error) {
+	patterns := make([]string, 0, 1)
+	for _, pattern := range strings.Split(c.Patterns, "\n") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	var numberTypes, operators map[string][]string
+	if c.NumberTypes != "" {
+		if err := json.Unmarshal([]byte(c.NumberTypes), &numberTypes); err != nil {
+			return nil, fmt.Errorf("country %q has bad number types: %w", c.CountryName, err)
+		}
+	}
+	if c.Operators != "" {
+		if err := json.Unmarshal([]byte(c.Operators), &operators); err != nil {
+			return nil, fmt.Errorf("country %q has bad operators: %w", c.CountryName, err)
+		}
+	}
+	return &phoneutils.CountryRule{
+		CountryName: c.CountryName,
+		ISOCode:     c.ISOCode,
+		DialCode:    c.CountryCode,
+		TrunkPrefix: c.TrunkPrefix,
+		Patterns:    patterns,
+		MinLength:   c.MinLength,
+		MaxLength:   c.MaxLength,
+		NumberTypes: numberTypes,
+		Operators:   operators,
+	}, nil
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// Customer owns phone records, phones reference it by CustomerID and keep it from being deleted
+type Customer struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement"`
+	Name       string    `gorm:"type:varchar(64)"`
+	Email      string    `gorm:"index;type:varchar(128)"`
+	Metadata   string    `gorm:"type:text"` // json object of string values
+	CreateDate time.Time `gorm:"autoCreateTime"`
+	UpdateDate time.Time `gorm:"autoUpdateTime"`
+	Phones     []*Phone  `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+}
+
+func (*Customer) TableName() string {
+	return "customers"
+}
@@ -1,14 +1,29 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Phone struct {
-	ID         uint `gorm:"primaryKey;autoIncrement"`
-	Country    `gorm:"embedded"`
-	Number     string    `gorm:"index;type:varchar(20);"`
-	CustId     string    `gorm:"index;type:varchar(32);"`
-	PhoneValid bool      `gorm:"index;type:tinyint(1)"`
-	CreateDate time.Time `gorm:"index;autoCreateTime"`
+	ID                uint           `gorm:"primaryKey;autoIncrement"`
+	CountryID         uint           `gorm:"index"`
+	Country           *Country       `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+	Number            string         `gorm:"index;type:varchar(20);"`
+	NumberE164        string         `gorm:"index;type:varchar(16);"`
+	NationalNumber    string         `gorm:"index;type:varchar(20);"` // digits only, for prefix search
+	NumberReversed    string         `gorm:"index;type:varchar(20);"` // E.164 digits backwards, for suffix search
+	NumberType        string         `gorm:"index;type:varchar(16);"`
+	Operator          string         `gorm:"index;type:varchar(32);"`
+	CustomerID        *uint          `gorm:"index"` // records may have no customer
+	PhoneValid        bool           `gorm:"index"`
+	ValidationReasons string         `gorm:"type:varchar(128)"` // comma separated
+	RuleVersion       string         `gorm:"type:varchar(20)"`
+	CreateDate        time.Time      `gorm:"index;autoCreateTime"`
+	UpdateDate        *time.Time     // set by the repository on updates, nil until the first one
+	DeletedAt         gorm.DeletedAt `gorm:"index"`
+	DeletedBy         string         `gorm:"type:varchar(32)"`
 }
 
 func (*Phone) TableName() string {
@@ -0,0 +1,461 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gidyon/jumia-exercise/internal/database"
+	"github.com/gidyon/jumia-exercise/internal/models"
+	"github.com/gidyon/jumia-exercise/pkg/utils/phoneutils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// createBatchSize is how many rows are inserted per statement
+const createBatchSize = 200
+
+// orderExprs are the qualified columns of OrderColumns, phone queries join the countries table
+var orderExprs = map[string]string{
+	"id":           "phones.id",
+	"country_name": "countries.country_name",
+	"country_code": "countries.country_code",
+	"number":       "phones.number",
+	"number_e164":  "phones.number_e164",
+	"create_date":  "phones.create_date",
+}
+
+// NewGorm creates a repository storing records in the tables of db, the tables are created by the migrations package
+func NewGorm(db *gorm.DB) PhoneRepository {
+	return &gormRepository{db: db}
+}
+
+type gormRepository struct {
+	db *gorm.DB
+}
+
+// notFound converts gorm.ErrRecordNotFound to ErrNotFound
+func notFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// missingReference converts the foreign key errors of writing phone records to ErrMissingReference
+func missingReference(err error) error {
+	if database.IsForeignKeyViolation(err) {
+		return fmt.Errorf("%w: %v", ErrMissingReference, err)
+	}
+	return err
+}
+
+// alreadyExists converts the unique index errors of writing countries to ErrAlreadyExists
+func alreadyExists(err error) error {
+	if database.IsUniqueViolation(err) {
+		return fmt.Errorf("%w: %v", ErrAlreadyExists, err)
+	}
+	return err
+}
+
+func (r *gormRepository) CreatePhone(ctx context.Context, phone *models.Phone) error {
+	return missingReference(r.db.WithContext(ctx).Omit(clause.Associations).Create(phone).Error)
+}
+
+func (r *gormRepository) CreatePhones(ctx context.Context, phones []*models.Phone) error {
+	// Batches would be created in a nested transaction otherwise, which needs savepoints
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Session(&gorm.Session{SkipDefaultTransaction: true}).
+			Omit(clause.Associations).CreateInBatches(phones, createBatchSize).Error
+	})
+	return missingReference(err)
+}
+
+func (r *gormRepository) TryCreatePhones(ctx context.Context, phones []*models.Phone) ([]error, error) {
+	errs := make([]error, len(phones))
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for start := 0; start < len(phones); start += createBatchSize {
+			end := start + createBatchSize
+			if end > len(phones) {
+				end = len(phones)
+			}
+
+			// A failed batch is retried row by row so only the failing rows are reported
+			err := tx.Transaction(func(tx *gorm.DB) error {
+				return tx.Omit(clause.Associations).CreateInBatches(phones[start:end], createBatchSize).Error
+			})
+			if err == nil {
+				continue
+			}
+			for j := start; j < end; j++ {
+				phones[j].ID = 0
+				errs[j] = missingReference(tx.Transaction(func(tx *gorm.DB) error {
+					return tx.Omit(clause.Associations).Create(phones[j]).Error
+				}))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return errs, nil
+}
+
+func (r *gormRepository) GetPhone(ctx context.Context, id uint) (*models.Phone, error) {
+	phone := &models.Phone{}
+	err := r.db.WithContext(ctx).Preload("Country").First(phone, "id = ?", id).Error
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return phone, nil
+}
+
+func (r *gormRepository) SavePhone(ctx context.Context, phone *models.Phone) error {
+	return savePhone(r.db.WithContext(ctx), phone)
+}
+
+// savePhone saves phone with the time of the update
+func savePhone(db *gorm.DB, phone *models.Phone) error {
+	now := db.NowFunc()
+	phone.UpdateDate = &now
+	return missingReference(db.Omit(clause.Associations).Save(phone).Error)
+}
+
+func (r *gormRepository) UpdatePhone(ctx context.Context, id uint, fn func(*models.Phone) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The row stays locked until the transaction ends. sqlite has no row locks, a concurrent write fails there instead.
+		phone := &models.Phone{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Country").First(phone, "id = ?", id).Error
+		if err != nil {
+			return notFound(err)
+		}
+
+		err = fn(phone)
+		if err != nil {
+			return err
+		}
+
+		return savePhone(tx, phone)
+	})
+}
+
+func (r *gormRepository) DeletePhones(ctx context.Context, ids []uint, deletedBy string, all bool) ([]uint, error) {
+	ids = uniqueIDs(ids)
+	moved := make([]uint, 0, len(ids))
+	if len(ids) == 0 {
+		return moved, nil
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Records are moved one by one, a record moved by a delete running at the same time is not updated again
+		deletedAt := time.Now()
+		for _, id := range ids {
+			res := tx.Model(&models.Phone{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+				"deleted_at": deletedAt,
+				"deleted_by": deletedBy,
+			})
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected != 0 {
+				moved = append(moved, id)
+			}
+		}
+		if all && len(moved) != len(ids) {
+			return ErrNotFound
+		}
+		return nil
+	})
+	return moved, err
+}
+
+func (r *gormRepository) RestorePhone(ctx context.Context, id uint) error {
+	tx := r.db.WithContext(ctx).Unscoped().Model(&models.Phone{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		UpdateColumns(map[string]interface{}{
+			"deleted_at": nil,
+			"deleted_by": "",
+		})
+	switch {
+	case tx.Error != nil:
+		return tx.Error
+	case tx.RowsAffected == 0:
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *gormRepository) PurgePhones(ctx context.Context, before time.Time) (int64, error) {
+	tx := r.db.WithContext(ctx).Unscoped().Where("deleted_at < ?", before).Delete(&models.Phone{})
+	return tx.RowsAffected, tx.Error
+}
+
+func (r *gormRepository) CountPhones(ctx context.Context, query *PhoneQuery) (int64, error) {
+	var count int64
+	err := r.phones(ctx, query).Count(&count).Error
+	return count, err
+}
+
+func (r *gormRepository) ListPhones(ctx context.Context, query *PhoneQuery) ([]*models.Phone, error) {
+	db, err := r.orderedPhones(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Countries are joined for filters and orders, their rows are preloaded
+	phones := make([]*models.Phone, 0, query.Limit)
+	err = db.Select("phones.*").Preload("Country").Find(&phones).Error
+	if err != nil {
+		return nil, err
+	}
+	return phones, nil
+}
+
+func (r *gormRepository) RangePhones(ctx context.Context, query *PhoneQuery, fn func(*models.Phone) error) error {
+	// Rows cannot preload, the few countries are read up front
+	countries, err := r.ListCountries(ctx, nil)
+	if err != nil {
+		return err
+	}
+	countryByID := make(map[uint]*models.Country, len(countries))
+	for _, country := range countries {
+		countryByID[country.ID] = country
+	}
+
+	db, err := r.orderedPhones(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	// Rows are read from a single cursor so memory use does not grow with the result
+	rows, err := db.Select("phones.*").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		phone := &models.Phone{}
+		err = r.db.ScanRows(rows, phone)
+		if err != nil {
+			return err
+		}
+		phone.Country = countryByID[phone.CountryID]
+		err = fn(phone)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// phones selects the phone records matching the trash scope and filters of query
+func (r *gormRepository) phones(ctx context.Context, query *PhoneQuery) *gorm.DB {
+	db := r.db.WithContext(ctx).Model(&models.Phone{})
+	switch query.Trash {
+	case OnlyDeleted:
+		db = db.Unscoped().Where("phones.deleted_at IS NOT NULL")
+	case WithDeleted:
+		db = db.Unscoped()
+	}
+
+	// Countries are joined so filters and orders can use their columns
+	db = db.Joins("LEFT JOIN countries ON countries.id = phones.country_id")
+
+	return filterPhones(db, &query.Filters)
+}
+
+// orderedPhones selects the phone records of query in its order, after its cursor and up to its limit
+func (r *gormRepository) orderedPhones(ctx context.Context, query *PhoneQuery) (*gorm.DB, error) {
+	column := query.Order.Column
+	if column == "" {
+		column = "id"
+	}
+	expr, ok := orderExprs[column]
+	if !ok {
+		return nil, fmt.Errorf("cannot order phones by %q", column)
+	}
+
+	db := r.phones(ctx, query)
+
+	op, dir := ">", "ASC"
+	if query.Order.Desc {
+		op, dir = "<", "DESC"
+	}
+
+	// The column is from orderExprs so it is safe to write into SQL, records with the same sort value are told apart by id
+	switch {
+	case query.After == nil:
+	case column == "id":
+		db = db.Where("phones.id "+op+" ?", query.After.ID)
+	default:
+		db = db.Where(
+			fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND phones.id %[2]s ?))", expr, op),
+			query.After.Value, query.After.Value, query.After.ID,
+		)
+	}
+
+	if column == "id" {
+		db = db.Order("phones.id " + dir)
+	} else {
+		db = db.Order(fmt.Sprintf("%s %s, phones.id %s", expr, dir, dir))
+	}
+
+	if query.Limit > 0 {
+		db = db.Limit(query.Limit)
+	}
+
+	return db, nil
+}
+
+// filterPhones narrows a phones query to the records matching filters.
+// Prefix and suffix matches are index range scans on the search columns, contains has to scan.
+func filterPhones(db *gorm.DB, filters *PhoneFilters) *gorm.DB {
+	switch filters.NumberMatch {
+	case MatchNumber:
+		db = db.Where("phones.number = ?", filters.Number)
+	case MatchE164:
+		db = db.Where("phones.number_e164 = ?", filters.Number)
+	case MatchE164Prefix:
+		db = prefixRange(db, "phones.number_e164", "+", filters.Number)
+	case MatchNationalPrefix:
+		db = prefixRange(db, "phones.national_number", "", filters.Number)
+	case MatchSuffix:
+		db = prefixRange(db, "phones.number_reversed", "", phoneutils.ReverseDigits(filters.Number))
+	case MatchContains:
+		db = db.Where(
+			"(phones.national_number LIKE ? OR phones.number_e164 LIKE ?)", "%"+filters.Number+"%", "%"+filters.Number+"%",
+		)
+	}
+
+	if filters.CountryCode != "" {
+		db = db.Where("countries.country_code = ?", filters.CountryCode)
+	}
+	if filters.NumberType != "" {
+		db = db.Where("phones.number_type = ?", filters.NumberType)
+	}
+	if filters.Operator != "" {
+		db = db.Where("phones.operator = ?", filters.Operator)
+	}
+	if len(filters.CustomerIDs) != 0 {
+		db = db.Where("phones.customer_id IN (?)", filters.CustomerIDs)
+	}
+	if !filters.CreatedAfter.IsZero() {
+		db = db.Where("phones.create_date >= ?", filters.CreatedAfter)
+	}
+	if !filters.CreatedBefore.IsZero() {
+		db = db.Where("phones.create_date < ?", filters.CreatedBefore)
+	}
+	if filters.Valid != nil {
+		db = db.Where("phones.phone_valid = ?", *filters.Valid)
+	}
+
+	return db
+}
+
+// prefixRange matches column values starting with lead followed by digits
+func prefixRange(db *gorm.DB, column, lead, digits string) *gorm.DB {
+	db = db.Where(column+" >= ?", lead+digits)
+	if upper := phoneutils.PrefixUpperBound(digits); upper != "" {
+		db = db.Where(column+" < ?", lead+upper)
+	}
+	return db
+}
+
+func (r *gormRepository) CreateCountry(ctx context.Context, country *models.Country) error {
+	return alreadyExists(r.db.WithContext(ctx).Create(country).Error)
+}
+
+func (r *gormRepository) GetCountry(ctx context.Context, id uint) (*models.Country, error) {
+	country := &models.Country{}
+	err := r.db.WithContext(ctx).First(country, "id = ?", id).Error
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return country, nil
+}
+
+func (r *gormRepository) SaveCountry(ctx context.Context, country *models.Country) error {
+	return alreadyExists(r.db.WithContext(ctx).Save(country).Error)
+}
+
+func (r *gormRepository) ListCountries(ctx context.Context, filter *CountryFilter) ([]*models.Country, error) {
+	if filter == nil {
+		filter = &CountryFilter{}
+	}
+
+	db := r.db.WithContext(ctx).Order("country_name").Order("id")
+	if filter.Name != "" {
+		db = db.Where("LOWER(country_name) = ?", strings.ToLower(filter.Name))
+	}
+	if filter.CountryCode != 0 {
+		db = db.Where("country_code = ?", filter.CountryCode)
+	}
+	if filter.EnabledOnly {
+		db = db.Where("disabled = ?", false)
+	}
+
+	countries := make([]*models.Country, 0, 10)
+	err := db.Find(&countries).Error
+	if err != nil {
+		return nil, err
+	}
+	return countries, nil
+}
+
+func (r *gormRepository) CreateCustomer(ctx context.Context, customer *models.Customer) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Create(customer).Error
+}
+
+func (r *gormRepository) GetCustomer(ctx context.Context, id uint) (*models.Customer, error) {
+	customer := &models.Customer{}
+	err := r.db.WithContext(ctx).First(customer, "id = ?", id).Error
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return customer, nil
+}
+
+func (r *gormRepository) SaveCustomer(ctx context.Context, customer *models.Customer) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Save(customer).Error
+}
+
+func (r *gormRepository) DeleteCustomer(ctx context.Context, id uint) error {
+	// The database keeps customers with phone records
+	tx := r.db.WithContext(ctx).Delete(&models.Customer{}, "id = ?", id)
+	switch {
+	case database.IsForeignKeyViolation(tx.Error):
+		return fmt.Errorf("%w: %v", ErrReferenced, tx.Error)
+	case tx.Error != nil:
+		return tx.Error
+	case tx.RowsAffected == 0:
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *gormRepository) CountCustomers(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Customer{}).Count(&count).Error
+	return count, err
+}
+
+func (r *gormRepository) ListCustomers(ctx context.Context, beforeID uint, limit int) ([]*models.Customer, error) {
+	db := r.db.WithContext(ctx).Order("id DESC").Limit(limit)
+	if beforeID != 0 {
+		db = db.Where("id < ?", beforeID)
+	}
+
+	customers := make([]*models.Customer, 0, limit)
+	err := db.Find(&customers).Error
+	if err != nil {
+		return nil, err
+	}
+	return customers, nil
+}
@@ -0,0 +1,619 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gidyon/jumia-exercise/internal/models"
+	"gorm.io/gorm"
+)
+
+// NewMemory creates a repository keeping records in memory, it is meant for tests and trying the services out.
+// Records are copied in and out so callers never share them with the repository.
+func NewMemory() PhoneRepository {
+	return &memoryRepository{
+		phones:    make(map[uint]*models.Phone),
+		countries: make(map[uint]*models.Country),
+		customers: make(map[uint]*models.Customer),
+	}
+}
+
+type memoryRepository struct {
+	mu sync.RWMutex
+	// updates is held by UpdatePhone, it stands for the row locks of a database
+	updates sync.Mutex
+
+	phones    map[uint]*models.Phone
+	countries map[uint]*models.Country
+	customers map[uint]*models.Customer
+
+	lastPhoneID    uint
+	lastCountryID  uint
+	lastCustomerID uint
+}
+
+// copyPhone copies phone without its country
+func copyPhone(phone *models.Phone) *models.Phone {
+	clone := *phone
+	clone.Country = nil
+	if phone.CustomerID != nil {
+		customerID := *phone.CustomerID
+		clone.CustomerID = &customerID
+	}
+	if phone.UpdateDate != nil {
+		updateDate := *phone.UpdateDate
+		clone.UpdateDate = &updateDate
+	}
+	return &clone
+}
+
+func copyCountry(country *models.Country) *models.Country {
+	clone := *country
+	return &clone
+}
+
+func copyCustomer(customer *models.Customer) *models.Customer {
+	clone := *customer
+	return &clone
+}
+
+// loadPhone copies a stored phone with its country
+func (r *memoryRepository) loadPhone(phone *models.Phone) *models.Phone {
+	clone := copyPhone(phone)
+	if country, ok := r.countries[phone.CountryID]; ok {
+		clone.Country = copyCountry(country)
+	}
+	return clone
+}
+
+// checkCustomer returns ErrMissingReference when phone belongs to a customer that does not exist, like a foreign key.
+// The caller holds the lock.
+func (r *memoryRepository) checkCustomer(phone *models.Phone) error {
+	if phone.CustomerID == nil {
+		return nil
+	}
+	if _, ok := r.customers[*phone.CustomerID]; !ok {
+		return fmt.Errorf("%w: customer %d", ErrMissingReference, *phone.CustomerID)
+	}
+	return nil
+}
+
+// createPhone stores phone, the caller holds the write lock
+func (r *memoryRepository) createPhone(phone *models.Phone) error {
+	if err := r.checkCustomer(phone); err != nil {
+		return err
+	}
+
+	if phone.ID == 0 {
+		r.lastPhoneID++
+		phone.ID = r.lastPhoneID
+	} else if _, ok := r.phones[phone.ID]; ok {
+		return fmt.Errorf("phone record %d already exists", phone.ID)
+	} else if phone.ID > r.lastPhoneID {
+		r.lastPhoneID = phone.ID
+	}
+
+	if phone.CreateDate.IsZero() {
+		phone.CreateDate = time.Now()
+	}
+
+	r.phones[phone.ID] = copyPhone(phone)
+	return nil
+}
+
+func (r *memoryRepository) CreatePhone(ctx context.Context, phone *models.Phone) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.createPhone(phone)
+}
+
+func (r *memoryRepository) CreatePhones(ctx context.Context, phones []*models.Phone) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Nothing is stored when a record cannot be
+	seen := make(map[uint]bool, len(phones))
+	for _, phone := range phones {
+		if phone.ID == 0 {
+			continue
+		}
+		if _, ok := r.phones[phone.ID]; ok || seen[phone.ID] {
+			return fmt.Errorf("phone record %d already exists", phone.ID)
+		}
+		seen[phone.ID] = true
+	}
+	for _, phone := range phones {
+		if err := r.checkCustomer(phone); err != nil {
+			return err
+		}
+	}
+
+	for _, phone := range phones {
+		if err := r.createPhone(phone); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *memoryRepository) TryCreatePhones(ctx context.Context, phones []*models.Phone) ([]error, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	errs := make([]error, len(phones))
+	for i, phone := range phones {
+		errs[i] = r.createPhone(phone)
+	}
+	return errs, nil
+}
+
+func (r *memoryRepository) GetPhone(ctx context.Context, id uint) (*models.Phone, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	phone, ok := r.phones[id]
+	if !ok || phone.DeletedAt.Valid {
+		return nil, ErrNotFound
+	}
+	return r.loadPhone(phone), nil
+}
+
+func (r *memoryRepository) SavePhone(ctx context.Context, phone *models.Phone) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.phones[phone.ID]; !ok {
+		return r.createPhone(phone)
+	}
+	return r.savePhone(phone)
+}
+
+// savePhone replaces a stored phone with the time of the update, the caller holds the write lock
+func (r *memoryRepository) savePhone(phone *models.Phone) error {
+	if err := r.checkCustomer(phone); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	phone.UpdateDate = &now
+	r.phones[phone.ID] = copyPhone(phone)
+	return nil
+}
+
+func (r *memoryRepository) UpdatePhone(ctx context.Context, id uint, fn func(*models.Phone) error) error {
+	// Only updates wait for each other while fn runs, other calls go on
+	r.updates.Lock()
+	defer r.updates.Unlock()
+
+	phone, err := r.GetPhone(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = fn(phone)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// The record may have been moved to the trash meanwhile
+	if stored, ok := r.phones[id]; !ok || stored.DeletedAt.Valid {
+		return ErrNotFound
+	}
+	phone.ID = id
+	return r.savePhone(phone)
+}
+
+func (r *memoryRepository) DeletePhones(ctx context.Context, ids []uint, deletedBy string, all bool) ([]uint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids = uniqueIDs(ids)
+	moved := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if phone, ok := r.phones[id]; ok && !phone.DeletedAt.Valid {
+			moved = append(moved, id)
+		}
+	}
+	if all && len(moved) != len(ids) {
+		return moved, ErrNotFound
+	}
+
+	now := time.Now()
+	for _, id := range moved {
+		phone := r.phones[id]
+		phone.DeletedAt = gorm.DeletedAt{Time: now, Valid: true}
+		phone.DeletedBy = deletedBy
+	}
+	return moved, nil
+}
+
+func (r *memoryRepository) RestorePhone(ctx context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	phone, ok := r.phones[id]
+	if !ok || !phone.DeletedAt.Valid {
+		return ErrNotFound
+	}
+	phone.DeletedAt = gorm.DeletedAt{}
+	phone.DeletedBy = ""
+	return nil
+}
+
+func (r *memoryRepository) PurgePhones(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var purged int64
+	for id, phone := range r.phones {
+		if phone.DeletedAt.Valid && phone.DeletedAt.Time.Before(before) {
+			delete(r.phones, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+func (r *memoryRepository) CountPhones(ctx context.Context, query *PhoneQuery) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int64
+	for _, phone := range r.phones {
+		if r.matchPhone(r.loadPhone(phone), query) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryRepository) ListPhones(ctx context.Context, query *PhoneQuery) ([]*models.Phone, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.orderedPhones(query)
+}
+
+func (r *memoryRepository) RangePhones(ctx context.Context, query *PhoneQuery, fn func(*models.Phone) error) error {
+	r.mu.RLock()
+	phones, err := r.orderedPhones(query)
+	r.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	for _, phone := range phones {
+		if err := fn(phone); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// orderedPhones copies the phone records of query in its order, after its cursor and up to its limit
+func (r *memoryRepository) orderedPhones(query *PhoneQuery) ([]*models.Phone, error) {
+	column := query.Order.Column
+	if column == "" {
+		column = "id"
+	}
+	if SortValue(&models.Phone{}, column) == nil {
+		return nil, fmt.Errorf("cannot order phones by %q", column)
+	}
+
+	// before tells whether a comes first in the order, ties are broken by id in the same direction
+	before := func(a, b *models.Phone) bool {
+		c := compareValues(SortValue(a, column), SortValue(b, column))
+		if c == 0 {
+			c = compareValues(a.ID, b.ID)
+		}
+		if query.Order.Desc {
+			return c > 0
+		}
+		return c < 0
+	}
+
+	var cursor *models.Phone
+	if query.After != nil {
+		cursor = phoneAt(column, query.After)
+	}
+
+	phones := make([]*models.Phone, 0, len(r.phones))
+	for _, phone := range r.phones {
+		phone = r.loadPhone(phone)
+		if !r.matchPhone(phone, query) || cursor != nil && !before(cursor, phone) {
+			continue
+		}
+		phones = append(phones, phone)
+	}
+
+	sort.Slice(phones, func(i, j int) bool { return before(phones[i], phones[j]) })
+
+	if query.Limit > 0 && len(phones) > query.Limit {
+		phones = phones[:query.Limit]
+	}
+	return phones, nil
+}
+
+// phoneAt returns a phone with the id and sort value of cursor in column
+func phoneAt(column string, cursor *Cursor) *models.Phone {
+	phone := &models.Phone{ID: cursor.ID, Country: &models.Country{}}
+	switch column {
+	case "country_name":
+		phone.Country.CountryName, _ = cursor.Value.(string)
+	case "country_code":
+		phone.Country.CountryCode, _ = cursor.Value.(uint)
+	case "number":
+		phone.Number, _ = cursor.Value.(string)
+	case "number_e164":
+		phone.NumberE164, _ = cursor.Value.(string)
+	case "create_date":
+		phone.CreateDate, _ = cursor.Value.(time.Time)
+	}
+	return phone
+}
+
+// compareValues compares two sort values of the same type, as returned by SortValue
+func compareValues(a, b interface{}) int {
+	switch a := a.(type) {
+	case uint:
+		b := b.(uint)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	case string:
+		return strings.Compare(a, b.(string))
+	case time.Time:
+		b := b.(time.Time)
+		switch {
+		case a.Before(b):
+			return -1
+		case a.After(b):
+			return 1
+		}
+		return 0
+	default:
+		return 0
+	}
+}
+
+// matchPhone tells whether a loaded phone is selected by the trash scope and filters of query
+func (r *memoryRepository) matchPhone(phone *models.Phone, query *PhoneQuery) bool {
+	switch query.Trash {
+	case NotDeleted:
+		if phone.DeletedAt.Valid {
+			return false
+		}
+	case OnlyDeleted:
+		if !phone.DeletedAt.Valid {
+			return false
+		}
+	}
+
+	filters := &query.Filters
+	switch {
+	case !filters.matchNumber(phone):
+		return false
+	case filters.CountryCode != "" && fmt.Sprint(phoneCountry(phone).CountryCode) != filters.CountryCode:
+		return false
+	case filters.NumberType != "" && phone.NumberType != filters.NumberType:
+		return false
+	case filters.Operator != "" && phone.Operator != filters.Operator:
+		return false
+	case len(filters.CustomerIDs) != 0 && (phone.CustomerID == nil || !containsUint(filters.CustomerIDs, *phone.CustomerID)):
+		return false
+	case !filters.CreatedAfter.IsZero() && phone.CreateDate.Before(filters.CreatedAfter):
+		return false
+	case !filters.CreatedBefore.IsZero() && !phone.CreateDate.Before(filters.CreatedBefore):
+		return false
+	case filters.Valid != nil && phone.PhoneValid != *filters.Valid:
+		return false
+	}
+	return true
+}
+
+func containsUint(values []uint, value uint) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *memoryRepository) CreateCountry(ctx context.Context, country *models.Country) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.createCountry(country)
+}
+
+// createCountry stores country, the caller holds the write lock
+func (r *memoryRepository) createCountry(country *models.Country) error {
+	if err := r.checkCountryName(country); err != nil {
+		return err
+	}
+	if country.ID == 0 {
+		r.lastCountryID++
+		country.ID = r.lastCountryID
+	} else if _, ok := r.countries[country.ID]; ok {
+		return fmt.Errorf("country %d already exists", country.ID)
+	} else if country.ID > r.lastCountryID {
+		r.lastCountryID = country.ID
+	}
+
+	r.countries[country.ID] = copyCountry(country)
+	return nil
+}
+
+func (r *memoryRepository) GetCountry(ctx context.Context, id uint) (*models.Country, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	country, ok := r.countries[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return copyCountry(country), nil
+}
+
+func (r *memoryRepository) SaveCountry(ctx context.Context, country *models.Country) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.countries[country.ID]; !ok {
+		return r.createCountry(country)
+	}
+	if err := r.checkCountryName(country); err != nil {
+		return err
+	}
+
+	r.countries[country.ID] = copyCountry(country)
+	return nil
+}
+
+// checkCountryName makes sure no other country has the name of country as the unique index of the database does,
+// the caller holds the write lock
+func (r *memoryRepository) checkCountryName(country *models.Country) error {
+	for _, other := range r.countries {
+		if other.ID != country.ID && strings.ToLower(other.CountryName) == strings.ToLower(country.CountryName) {
+			return fmt.Errorf("%w: country %q", ErrAlreadyExists, other.CountryName)
+		}
+	}
+	return nil
+}
+
+func (r *memoryRepository) ListCountries(ctx context.Context, filter *CountryFilter) ([]*models.Country, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if filter == nil {
+		filter = &CountryFilter{}
+	}
+
+	countries := make([]*models.Country, 0, len(r.countries))
+	for _, country := range r.countries {
+		switch {
+		case filter.Name != "" && strings.ToLower(country.CountryName) != strings.ToLower(filter.Name):
+		case filter.CountryCode != 0 && country.CountryCode != filter.CountryCode:
+		case filter.EnabledOnly && country.Disabled:
+		default:
+			countries = append(countries, copyCountry(country))
+		}
+	}
+
+	sort.Slice(countries, func(i, j int) bool {
+		if countries[i].CountryName != countries[j].CountryName {
+			return countries[i].CountryName < countries[j].CountryName
+		}
+		return countries[i].ID < countries[j].ID
+	})
+	return countries, nil
+}
+
+func (r *memoryRepository) CreateCustomer(ctx context.Context, customer *models.Customer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.createCustomer(customer)
+}
+
+// createCustomer stores customer, the caller holds the write lock
+func (r *memoryRepository) createCustomer(customer *models.Customer) error {
+	if customer.ID == 0 {
+		r.lastCustomerID++
+		customer.ID = r.lastCustomerID
+	} else if _, ok := r.customers[customer.ID]; ok {
+		return fmt.Errorf("customer %d already exists", customer.ID)
+	} else if customer.ID > r.lastCustomerID {
+		r.lastCustomerID = customer.ID
+	}
+
+	now := time.Now()
+	if customer.CreateDate.IsZero() {
+		customer.CreateDate = now
+	}
+	if customer.UpdateDate.IsZero() {
+		customer.UpdateDate = now
+	}
+
+	r.customers[customer.ID] = copyCustomer(customer)
+	return nil
+}
+
+func (r *memoryRepository) GetCustomer(ctx context.Context, id uint) (*models.Customer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	customer, ok := r.customers[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return copyCustomer(customer), nil
+}
+
+func (r *memoryRepository) SaveCustomer(ctx context.Context, customer *models.Customer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.customers[customer.ID]; !ok {
+		return r.createCustomer(customer)
+	}
+
+	customer.UpdateDate = time.Now()
+	r.customers[customer.ID] = copyCustomer(customer)
+	return nil
+}
+
+func (r *memoryRepository) DeleteCustomer(ctx context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.customers[id]; !ok {
+		return ErrNotFound
+	}
+	for _, phone := range r.phones {
+		if phone.CustomerID != nil && *phone.CustomerID == id {
+			return fmt.Errorf("%w: phone record %d belongs to customer %d", ErrReferenced, phone.ID, id)
+		}
+	}
+	delete(r.customers, id)
+	return nil
+}
+
+func (r *memoryRepository) CountCustomers(ctx context.Context) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return int64(len(r.customers)), nil
+}
+
+func (r *memoryRepository) ListCustomers(ctx context.Context, beforeID uint, limit int) ([]*models.Customer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	customers := make([]*models.Customer, 0, len(r.customers))
+	for _, customer := range r.customers {
+		if beforeID == 0 || customer.ID < beforeID {
+			customers = append(customers, copyCustomer(customer))
+		}
+	}
+
+	sort.Slice(customers, func(i, j int) bool { return customers[i].ID > customers[j].ID })
+
+	if limit > 0 && len(customers) > limit {
+		customers = customers[:limit]
+	}
+	return customers, nil
+}
@@ -0,0 +1,227 @@
+// Package repository stores phone records with the countries and customers they reference
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gidyon/jumia-exercise/internal/models"
+	"github.com/gidyon/jumia-exercise/pkg/utils/phoneutils"
+)
+
+var (
+	// ErrNotFound is returned when the requested record does not exist
+	ErrNotFound = errors.New("record not found")
+	// ErrMissingReference is returned when a phone record references a customer that does not exist
+	ErrMissingReference = errors.New("referenced record does not exist")
+	// ErrReferenced is returned when deleting a customer phone records still reference, including those in the trash
+	ErrReferenced = errors.New("record is referenced by other records")
+	// ErrAlreadyExists is returned when saving a country with the name of another country, names are compared ignoring case
+	ErrAlreadyExists = errors.New("record already exists")
+)
+
+// PhoneRepository stores phone records. Records reference countries and customers, which are stored with them.
+type PhoneRepository interface {
+	CountryRepository
+	CustomerRepository
+
+	// CreatePhone adds a phone record and sets its id, the country it holds is not saved.
+	// Writing a record whose customer does not exist returns ErrMissingReference.
+	CreatePhone(ctx context.Context, phone *models.Phone) error
+	// CreatePhones adds every phone record or none of them
+	CreatePhones(ctx context.Context, phones []*models.Phone) error
+	// TryCreatePhones adds the phone records it can, the errors of the others are returned at their index
+	TryCreatePhones(ctx context.Context, phones []*models.Phone) ([]error, error)
+	// GetPhone returns a phone record that is not in the trash, with its country
+	GetPhone(ctx context.Context, id uint) (*models.Phone, error)
+	// SavePhone updates every field of a phone record and its update date, the country it holds is not saved
+	SavePhone(ctx context.Context, phone *models.Phone) error
+	// UpdatePhone reads a phone record that is not in the trash and saves the changes fn makes to it in one transaction,
+	// other updates of the record wait for it. Nothing is saved when fn fails, its error is returned.
+	// fn runs while the record is locked and must not use the repository, records it needs are read before.
+	UpdatePhone(ctx context.Context, id uint, fn func(*models.Phone) error) error
+	// DeletePhones moves phone records to the trash in one transaction and returns the ids it moved in the order of ids,
+	// repeated ids are moved once. Records that are not found or already in the trash are skipped unless all is set,
+	// then nothing is moved and ErrNotFound is returned with the ids that would have been moved.
+	DeletePhones(ctx context.Context, ids []uint, deletedBy string, all bool) ([]uint, error)
+	// RestorePhone takes a phone record out of the trash, it returns ErrNotFound when the record is not there
+	RestorePhone(ctx context.Context, id uint) error
+	// PurgePhones permanently removes records deleted before the given time and returns how many were removed
+	PurgePhones(ctx context.Context, before time.Time) (int64, error)
+	// CountPhones counts the phone records matching query, its order, cursor and limit are ignored
+	CountPhones(ctx context.Context, query *PhoneQuery) (int64, error)
+	// ListPhones returns the phone records matching query with their countries
+	ListPhones(ctx context.Context, query *PhoneQuery) ([]*models.Phone, error)
+	// RangePhones calls fn with every phone record matching query and its country, it stops at the first error
+	RangePhones(ctx context.Context, query *PhoneQuery, fn func(*models.Phone) error) error
+}
+
+// CountryRepository stores the countries of phone records
+type CountryRepository interface {
+	// CreateCountry returns ErrAlreadyExists when another country has the name of country
+	CreateCountry(ctx context.Context, country *models.Country) error
+	GetCountry(ctx context.Context, id uint) (*models.Country, error)
+	// SaveCountry returns ErrAlreadyExists when another country has the name of country
+	SaveCountry(ctx context.Context, country *models.Country) error
+	// ListCountries returns the countries matching filter ordered by name, then id
+	ListCountries(ctx context.Context, filter *CountryFilter) ([]*models.Country, error)
+}
+
+// CountryFilter selects countries, empty fields match every country
+type CountryFilter struct {
+	// Name is compared ignoring case
+	Name        string
+	CountryCode uint
+	EnabledOnly bool
+}
+
+// CustomerRepository stores the customers owning phone records
+type CustomerRepository interface {
+	CreateCustomer(ctx context.Context, customer *models.Customer) error
+	GetCustomer(ctx context.Context, id uint) (*models.Customer, error)
+	SaveCustomer(ctx context.Context, customer *models.Customer) error
+	// DeleteCustomer removes a customer, it returns ErrNotFound when there is no such customer and ErrReferenced when
+	// phone records still belong to it
+	DeleteCustomer(ctx context.Context, id uint) error
+	CountCustomers(ctx context.Context) (int64, error)
+	// ListCustomers returns at most limit customers newest first, starting before the given id when it is not 0
+	ListCustomers(ctx context.Context, beforeID uint, limit int) ([]*models.Customer, error)
+}
+
+// Trash selects phone records by whether they were deleted
+type Trash int
+
+const (
+	// NotDeleted selects records that are not in the trash
+	NotDeleted Trash = iota
+	// OnlyDeleted selects records in the trash
+	OnlyDeleted
+	// WithDeleted selects every record
+	WithDeleted
+)
+
+// PhoneQuery selects and orders phone records
+type PhoneQuery struct {
+	Trash   Trash
+	Filters PhoneFilters
+	Order   PhoneOrder
+	// After starts the records after the cursor in Order, nil starts from the first record
+	After *Cursor
+	// Limit is the most records returned, 0 returns every record
+	Limit int
+}
+
+// NumberMatch is how the Number filter is compared to phone numbers
+type NumberMatch int
+
+const (
+	// MatchAny does not filter numbers
+	MatchAny NumberMatch = iota
+	// MatchNumber matches numbers written exactly as the filter
+	MatchNumber
+	// MatchE164 matches the E.164 form of numbers
+	MatchE164
+	// MatchE164Prefix matches E.164 numbers starting with the filter digits after the plus sign
+	MatchE164Prefix
+	// MatchNationalPrefix matches national numbers starting with the filter digits
+	MatchNationalPrefix
+	// MatchSuffix matches numbers ending with the filter digits
+	MatchSuffix
+	// MatchContains matches national or E.164 numbers containing the filter digits
+	MatchContains
+)
+
+// PhoneFilters selects phone records, empty fields match every record
+type PhoneFilters struct {
+	NumberMatch NumberMatch
+	Number      string
+	CountryCode string
+	NumberType  string
+	Operator    string
+	CustomerIDs []uint
+	// CreatedAfter and CreatedBefore bound the create date, the first is inclusive
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	// Valid selects valid or invalid numbers when it is set
+	Valid *bool
+}
+
+// PhoneOrder sorts phone records by one of OrderColumns, ties are broken by id in the same direction
+type PhoneOrder struct {
+	// Column is one of OrderColumns, records are sorted by id when it is empty
+	Column string
+	Desc   bool
+}
+
+// Cursor is the position of a record in a PhoneOrder
+type Cursor struct {
+	ID uint
+	// Value is the sort value of the record as returned by SortValue, it is unused when sorting by id
+	Value interface{}
+}
+
+// OrderColumns are the columns phone records can be ordered by
+var OrderColumns = []string{"country_code", "country_name", "create_date", "id", "number", "number_e164"}
+
+// SortValue returns the value phone is ordered by in column, a uint, string or time.Time. It returns nil for unknown columns.
+func SortValue(phone *models.Phone, column string) interface{} {
+	switch column {
+	case "", "id":
+		return phone.ID
+	case "country_name":
+		return phoneCountry(phone).CountryName
+	case "country_code":
+		return phoneCountry(phone).CountryCode
+	case "number":
+		return phone.Number
+	case "number_e164":
+		return phone.NumberE164
+	case "create_date":
+		return phone.CreateDate
+	default:
+		return nil
+	}
+}
+
+// phoneCountry returns the country loaded with a phone, or an empty country when it was not loaded
+// uniqueIDs returns ids without repeats, in the order they first appear
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	res := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			res = append(res, id)
+		}
+	}
+	return res
+}
+
+func phoneCountry(phone *models.Phone) *models.Country {
+	if phone.Country == nil {
+		return &models.Country{}
+	}
+	return phone.Country
+}
+
+// matchNumber tells whether the number of phone matches the Number filter
+func (f *PhoneFilters) matchNumber(phone *models.Phone) bool {
+	switch f.NumberMatch {
+	case MatchNumber:
+		return phone.Number == f.Number
+	case MatchE164:
+		return phone.NumberE164 == f.Number
+	case MatchE164Prefix:
+		return strings.HasPrefix(phone.NumberE164, "+"+f.Number)
+	case MatchNationalPrefix:
+		return strings.HasPrefix(phone.NationalNumber, f.Number)
+	case MatchSuffix:
+		return strings.HasPrefix(phone.NumberReversed, phoneutils.ReverseDigits(f.Number))
+	case MatchContains:
+		return strings.Contains(phone.NationalNumber, f.Number) || strings.Contains(phone.NumberE164, f.Number)
+	default:
+		return true
+	}
+}
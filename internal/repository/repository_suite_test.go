@@ -0,0 +1,357 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gidyon/jumia-exercise/internal/database"
+	"github.com/gidyon/jumia-exercise/internal/migrations"
+	"github.com/gidyon/jumia-exercise/internal/models"
+	"github.com/gidyon/jumia-exercise/pkg/utils/phoneutils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestRepository(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Repository Suite")
+}
+
+var databases int
+
+// newGorm creates a repository on a new migrated in memory sqlite database
+func newGorm() PhoneRepository {
+	databases++
+	db, err := database.Open(
+		database.DriverSQLite, fmt.Sprintf("file:repository_%d?mode=memory&cache=shared", databases),
+		&gorm.Config{Logger: logger.Default.LogMode(logger.Silent)},
+	)
+	Expect(err).ShouldNot(HaveOccurred())
+	_, err = migrations.Up(db, 0)
+	Expect(err).ShouldNot(HaveOccurred())
+	return NewGorm(db)
+}
+
+// phoneIDs returns the ids of phones in order
+func phoneIDs(phones []*models.Phone) []uint {
+	ids := make([]uint, 0, len(phones))
+	for _, phone := range phones {
+		ids = append(ids, phone.ID)
+	}
+	return ids
+}
+
+// Both repositories must select and order the same records
+var _ = Describe("Phone repository", func() {
+	for _, kind := range []struct {
+		name          string
+		newRepository func() PhoneRepository
+	}{{"gorm", newGorm}, {"memory", NewMemory}} {
+		newRepository := kind.newRepository
+
+		Describe(fmt.Sprintf("Storing records in %s", kind.name), func() {
+			var (
+				ctx       context.Context
+				repo      PhoneRepository
+				cameroon  *models.Country
+				uganda    *models.Country
+				phones    []*models.Phone
+				customers []*models.Customer
+			)
+
+			newPhone := func(country *models.Country, number string, customer *models.Customer) *models.Phone {
+				keys := phoneutils.NumberSearchKeys(number, country.CountryName)
+				phone := &models.Phone{
+					CountryID:      country.ID,
+					Number:         number,
+					NumberE164:     phoneutils.NormalizeE164(number, country.CountryName),
+					NationalNumber: keys.National,
+					NumberReversed: keys.Reversed,
+					PhoneValid:     true,
+				}
+				if customer != nil {
+					phone.CustomerID = &customer.ID
+				}
+				return phone
+			}
+
+			BeforeEach(func() {
+				ctx = context.Background()
+				repo = newRepository()
+
+				cameroon = &models.Country{CountryName: "Cameroon", CountryCode: 237}
+				uganda = &models.Country{CountryName: "Uganda", CountryCode: 256, Disabled: true}
+				Expect(repo.CreateCountry(ctx, uganda)).To(Succeed())
+				Expect(repo.CreateCountry(ctx, cameroon)).To(Succeed())
+
+				customers = []*models.Customer{{Name: "Jane"}, {Name: "John"}, {Name: "Joy"}}
+				for _, customer := range customers {
+					Expect(repo.CreateCustomer(ctx, customer)).To(Succeed())
+				}
+
+				phones = []*models.Phone{
+					newPhone(cameroon, "(237) 697151594", customers[0]),
+					newPhone(uganda, "(256) 704123456", customers[0]),
+					newPhone(cameroon, "(237) 677123456", customers[1]),
+					newPhone(uganda, "(256) 712151594", nil),
+				}
+				phones[2].PhoneValid = false
+				Expect(repo.CreatePhones(ctx, phones)).To(Succeed())
+			})
+
+			It("should get phone records with their country", func() {
+				phone, err := repo.GetPhone(ctx, phones[1].ID)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(phone.Number).To(Equal(phones[1].Number))
+				Expect(phone.Country.CountryName).To(Equal("Uganda"))
+				Expect(phone.CreateDate).NotTo(BeZero())
+
+				_, err = repo.GetPhone(ctx, phones[3].ID+100)
+				Expect(errors.Is(err, ErrNotFound)).To(BeTrue())
+			})
+
+			It("should save phone records", func() {
+				phone, err := repo.GetPhone(ctx, phones[0].ID)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(phone.UpdateDate).To(BeNil())
+				phone.CustomerID = &customers[2].ID
+				Expect(repo.SavePhone(ctx, phone)).To(Succeed())
+
+				phone, err = repo.GetPhone(ctx, phones[0].ID)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(*phone.CustomerID).To(Equal(customers[2].ID))
+				Expect(phone.UpdateDate).NotTo(BeNil())
+				Expect(phone.UpdateDate.After(phone.CreateDate)).To(BeTrue())
+			})
+
+			It("should update phone records in one transaction", func() {
+				err := repo.UpdatePhone(ctx, phones[1].ID, func(phone *models.Phone) error {
+					Expect(phone.Country.CountryName).To(Equal("Uganda"))
+					phone.Number = "(256) 704000000"
+					return nil
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				phone, err := repo.GetPhone(ctx, phones[1].ID)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(phone.Number).To(Equal("(256) 704000000"))
+				Expect(phone.UpdateDate.After(phone.CreateDate)).To(BeTrue())
+
+				// Nothing is saved when fn fails
+				failed := errors.New("failed")
+				err = repo.UpdatePhone(ctx, phones[1].ID, func(phone *models.Phone) error {
+					phone.Number = "(256) 704111111"
+					return failed
+				})
+				Expect(err).To(Equal(failed))
+				phone, err = repo.GetPhone(ctx, phones[1].ID)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(phone.Number).To(Equal("(256) 704000000"))
+
+				Expect(repo.DeletePhones(ctx, []uint{phones[1].ID}, "admin", false)).To(HaveLen(1))
+				err = repo.UpdatePhone(ctx, phones[1].ID, func(*models.Phone) error { return nil })
+				Expect(errors.Is(err, ErrNotFound)).To(BeTrue())
+			})
+
+			It("should only give phone records to customers that exist", func() {
+				missing := customers[2].ID + 100
+
+				phone := newPhone(cameroon, "(237) 699123456", &models.Customer{ID: missing})
+				Expect(errors.Is(repo.CreatePhone(ctx, phone), ErrMissingReference)).To(BeTrue())
+
+				phone = newPhone(cameroon, "(237) 699123456", &models.Customer{ID: missing})
+				Expect(errors.Is(repo.CreatePhones(ctx, []*models.Phone{phone}), ErrMissingReference)).To(BeTrue())
+
+				tried := []*models.Phone{
+					newPhone(cameroon, "(237) 699123456", customers[2]),
+					newPhone(cameroon, "(237) 699123457", &models.Customer{ID: missing}),
+				}
+				createErrs, err := repo.TryCreatePhones(ctx, tried)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(createErrs[0]).ShouldNot(HaveOccurred())
+				Expect(errors.Is(createErrs[1], ErrMissingReference)).To(BeTrue())
+
+				saved, err := repo.GetPhone(ctx, phones[3].ID)
+				Expect(err).ShouldNot(HaveOccurred())
+				saved.CustomerID = &missing
+				Expect(errors.Is(repo.SavePhone(ctx, saved), ErrMissingReference)).To(BeTrue())
+			})
+
+			It("should keep customers that have phone records, even in the trash", func() {
+				Expect(repo.DeletePhones(ctx, []uint{phones[2].ID}, "admin", false)).To(HaveLen(1))
+				Expect(errors.Is(repo.DeleteCustomer(ctx, customers[1].ID), ErrReferenced)).To(BeTrue())
+
+				_, err := repo.GetCustomer(ctx, customers[1].ID)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				_, err = repo.PurgePhones(ctx, time.Now().Add(time.Second))
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(repo.DeleteCustomer(ctx, customers[1].ID)).To(Succeed())
+			})
+
+			It("should filter phone records", func() {
+				valid := false
+				for _, c := range []struct {
+					filters PhoneFilters
+					want    []uint
+				}{
+					{PhoneFilters{NumberMatch: MatchE164, Number: "+237697151594"}, []uint{phones[0].ID}},
+					{PhoneFilters{NumberMatch: MatchNumber, Number: "(256) 704123456"}, []uint{phones[1].ID}},
+					{PhoneFilters{NumberMatch: MatchE164Prefix, Number: "256"}, []uint{phones[1].ID, phones[3].ID}},
+					{PhoneFilters{NumberMatch: MatchNationalPrefix, Number: "67"}, []uint{phones[2].ID}},
+					{PhoneFilters{NumberMatch: MatchSuffix, Number: "151594"}, []uint{phones[0].ID, phones[3].ID}},
+					{PhoneFilters{NumberMatch: MatchContains, Number: "1234"}, []uint{phones[1].ID, phones[2].ID}},
+					{PhoneFilters{CountryCode: "237"}, []uint{phones[0].ID, phones[2].ID}},
+					{PhoneFilters{CustomerIDs: []uint{customers[0].ID, customers[1].ID}}, []uint{phones[0].ID, phones[1].ID, phones[2].ID}},
+					{PhoneFilters{Valid: &valid}, []uint{phones[2].ID}},
+				} {
+					query := &PhoneQuery{Filters: c.filters}
+					list, err := repo.ListPhones(ctx, query)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(phoneIDs(list)).To(Equal(c.want), "%+v", c.filters)
+
+					count, err := repo.CountPhones(ctx, query)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(count).To(BeEquivalentTo(len(c.want)))
+				}
+			})
+
+			It("should filter phone records by create date", func() {
+				list, err := repo.ListPhones(ctx, &PhoneQuery{
+					Filters: PhoneFilters{CreatedAfter: time.Now().Add(-time.Hour), CreatedBefore: time.Now().Add(time.Hour)},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(list).To(HaveLen(len(phones)))
+
+				list, err = repo.ListPhones(ctx, &PhoneQuery{Filters: PhoneFilters{CreatedAfter: time.Now().Add(time.Hour)}})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(list).To(BeEmpty())
+			})
+
+			It("should order phone records and continue after a cursor", func() {
+				order := PhoneOrder{Column: "country_name", Desc: true}
+				list, err := repo.ListPhones(ctx, &PhoneQuery{Order: order, Limit: 3})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(phoneIDs(list)).To(Equal([]uint{phones[3].ID, phones[1].ID, phones[2].ID}))
+
+				last := list[len(list)-1]
+				list, err = repo.ListPhones(ctx, &PhoneQuery{
+					Order: order,
+					After: &Cursor{ID: last.ID, Value: SortValue(last, order.Column)},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(phoneIDs(list)).To(Equal([]uint{phones[0].ID}))
+
+				var ranged []uint
+				err = repo.RangePhones(ctx, &PhoneQuery{Order: PhoneOrder{Column: "number"}}, func(phone *models.Phone) error {
+					Expect(phone.Country).NotTo(BeNil())
+					ranged = append(ranged, phone.ID)
+					return nil
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(ranged).To(Equal([]uint{phones[2].ID, phones[0].ID, phones[1].ID, phones[3].ID}))
+			})
+
+			It("should move phone records to the trash, restore and purge them", func() {
+				moved, err := repo.DeletePhones(ctx, []uint{phones[1].ID, phones[0].ID, phones[1].ID}, "admin", false)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(moved).To(Equal([]uint{phones[1].ID, phones[0].ID}))
+
+				_, err = repo.GetPhone(ctx, phones[0].ID)
+				Expect(errors.Is(err, ErrNotFound)).To(BeTrue())
+
+				// Records already in the trash are not moved again
+				moved, err = repo.DeletePhones(ctx, []uint{phones[0].ID, phones[2].ID, 0}, "other", false)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(moved).To(Equal([]uint{phones[2].ID}))
+				Expect(repo.RestorePhone(ctx, phones[2].ID)).To(Succeed())
+
+				// Moving all of them or none moves none when one is missing
+				moved, err = repo.DeletePhones(ctx, []uint{phones[2].ID, phones[0].ID}, "other", true)
+				Expect(errors.Is(err, ErrNotFound)).To(BeTrue())
+				Expect(moved).To(Equal([]uint{phones[2].ID}))
+				_, err = repo.GetPhone(ctx, phones[2].ID)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				deleted, err := repo.ListPhones(ctx, &PhoneQuery{Trash: OnlyDeleted})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(phoneIDs(deleted)).To(Equal([]uint{phones[0].ID, phones[1].ID}))
+				Expect(deleted[0].DeletedBy).To(Equal("admin"))
+
+				count, err := repo.CountPhones(ctx, &PhoneQuery{Trash: WithDeleted})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(count).To(BeEquivalentTo(len(phones)))
+
+				Expect(repo.RestorePhone(ctx, phones[0].ID)).To(Succeed())
+				Expect(errors.Is(repo.RestorePhone(ctx, phones[0].ID), ErrNotFound)).To(BeTrue())
+
+				purged, err := repo.PurgePhones(ctx, time.Now().Add(time.Second))
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(purged).To(BeEquivalentTo(1))
+				count, err = repo.CountPhones(ctx, &PhoneQuery{Trash: WithDeleted})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(count).To(BeEquivalentTo(len(phones) - 1))
+			})
+
+			It("should list countries by name", func() {
+				countries, err := repo.ListCountries(ctx, nil)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(countries).To(HaveLen(2))
+				Expect(countries[0].CountryName).To(Equal("Cameroon"))
+
+				countries, err = repo.ListCountries(ctx, &CountryFilter{Name: "uGANDA"})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(countries).To(HaveLen(1))
+
+				countries, err = repo.ListCountries(ctx, &CountryFilter{CountryCode: 256, EnabledOnly: true})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(countries).To(BeEmpty())
+
+				uganda.Disabled = false
+				Expect(repo.SaveCountry(ctx, uganda)).To(Succeed())
+				country, err := repo.GetCountry(ctx, uganda.ID)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(country.Disabled).To(BeFalse())
+			})
+
+			It("should not store two countries with the same name", func() {
+				err := repo.CreateCountry(ctx, &models.Country{CountryName: "CAMEROON", CountryCode: 237})
+				Expect(errors.Is(err, ErrAlreadyExists)).To(BeTrue())
+
+				uganda.CountryName = "cameroon"
+				err = repo.SaveCountry(ctx, uganda)
+				Expect(errors.Is(err, ErrAlreadyExists)).To(BeTrue())
+
+				cameroon.CountryName = "Republic of Cameroon"
+				Expect(repo.SaveCountry(ctx, cameroon)).To(Succeed())
+				countries, err := repo.ListCountries(ctx, &CountryFilter{Name: "uganda"})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(countries).To(HaveLen(1))
+			})
+
+			It("should list customers newest first and delete them", func() {
+				list, err := repo.ListCustomers(ctx, 0, 2)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(list).To(HaveLen(2))
+				Expect(list[0].ID).To(Equal(customers[2].ID))
+
+				list, err = repo.ListCustomers(ctx, list[1].ID, 2)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(list).To(HaveLen(1))
+				Expect(list[0].ID).To(Equal(customers[0].ID))
+
+				Expect(repo.DeleteCustomer(ctx, customers[2].ID)).To(Succeed())
+				Expect(errors.Is(repo.DeleteCustomer(ctx, customers[2].ID), ErrNotFound)).To(BeTrue())
+
+				count, err := repo.CountCustomers(ctx)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(count).To(BeEquivalentTo(2))
+			})
+		})
+	}
+})
@@ -0,0 +1,28 @@
+// Package testutil has helpers shared by the test suites, it is not used by the service
+package testutil
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gidyon/jumia-exercise/internal/database"
+)
+
+// Backend is a database test suites run against
+type Backend struct {
+	Driver string
+	DSN    string
+}
+
+// Backends lists the databases test suites run against. sqlite always runs on sqliteDSN, PostgreSQL and MySQL
+// run when PHONEBOOK_TEST_POSTGRES_DSN or PHONEBOOK_TEST_MYSQL_DSN are set. Suites empty those databases.
+func Backends(sqliteDSN string) []*Backend {
+	backends := []*Backend{{Driver: database.DriverSQLite, DSN: sqliteDSN}}
+	for _, driver := range []string{database.DriverPostgres, database.DriverMySQL} {
+		if dsn := os.Getenv(fmt.Sprintf("PHONEBOOK_TEST_%s_DSN", strings.ToUpper(driver))); dsn != "" {
+			backends = append(backends, &Backend{Driver: driver, DSN: dsn})
+		}
+	}
+	return backends
+}
@@ -0,0 +1,34 @@
+package testutil
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gidyon/jumia-exercise/internal/database"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestTestutil(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Testutil Suite")
+}
+
+var _ = Describe("Testutil", func() {
+
+	Context("Listing test backends", func() {
+		It("should always run sqlite and others when their dsn is set", func() {
+			defer os.Setenv("PHONEBOOK_TEST_POSTGRES_DSN", os.Getenv("PHONEBOOK_TEST_POSTGRES_DSN"))
+			defer os.Setenv("PHONEBOOK_TEST_MYSQL_DSN", os.Getenv("PHONEBOOK_TEST_MYSQL_DSN"))
+
+			Expect(os.Setenv("PHONEBOOK_TEST_POSTGRES_DSN", "postgres://localhost/phonebook")).To(Succeed())
+			Expect(os.Setenv("PHONEBOOK_TEST_MYSQL_DSN", "")).To(Succeed())
+
+			backends := Backends("phones.db")
+			Expect(backends).To(Equal([]*Backend{
+				{Driver: database.DriverSQLite, DSN: "phones.db"},
+				{Driver: database.DriverPostgres, DSN: "postgres://localhost/phonebook"},
+			}))
+		})
+	})
+})
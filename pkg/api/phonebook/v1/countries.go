@@ -0,0 +1,67 @@
+package phonebook
+
+import "context"
+
+// CountryService manages the countries phone records belong to and the rules their numbers are validated with
+type CountryService interface {
+	CreateCountry(context.Context, *Country) (*Country, error)
+	GetCountry(context.Context, *GetCountryRequest) (*Country, error)
+	UpdateCountry(context.Context, *UpdateCountryRequest) (*Country, error)
+	ListCountries(context.Context, *ListCountriesRequest) (*ListCountriesResponse, error)
+	DisableCountry(context.Context, *DisableCountryRequest) (*Country, error)
+}
+
+// Country is a supported country with its validation rules. Disabled countries keep their phone records
+// but new records cannot be given to them
+type Country struct {
+	Id          string   `json:"id,omitempty"`
+	CountryName string   `json:"country_name,omitempty"`
+	CountryCode uint     `json:"country_code,omitempty"`
+	IsoCode     string   `json:"iso_code,omitempty"`
+	TrunkPrefix string   `json:"trunk_prefix,omitempty"`
+	Patterns    []string `json:"patterns,omitempty"`
+	MinLength   int      `json:"min_length,omitempty"`
+	MaxLength   int      `json:"max_length,omitempty"`
+	// NumberTypes maps a number type to national number prefixes, a prefix can be a range such as "82-87"
+	NumberTypes map[string][]string `json:"number_types,omitempty"`
+	// Operators maps an operator name to national number prefixes
+	Operators map[string][]string `json:"operators,omitempty"`
+	Disabled  bool                `json:"disabled,omitempty"`
+}
+
+type GetCountryRequest struct {
+	CountryId string `json:"country_id,omitempty"`
+}
+
+type UpdateCountryRequest struct {
+	Country    *Country `json:"country,omitempty"`
+	UpdateMask []string `json:"update_mask,omitempty"`
+}
+
+// Update mask paths accepted by UpdateCountry
+const (
+	UpdateMaskCountryName = "country_name"
+	UpdateMaskCountryCode = "country_code"
+	UpdateMaskIsoCode     = "iso_code"
+	UpdateMaskTrunkPrefix = "trunk_prefix"
+	UpdateMaskPatterns    = "patterns"
+	UpdateMaskMinLength   = "min_length"
+	UpdateMaskMaxLength   = "max_length"
+	UpdateMaskNumberTypes = "number_types"
+	UpdateMaskOperators   = "operators"
+	UpdateMaskDisabled    = "disabled"
+)
+
+// ListCountriesRequest lists countries by name, there are few so they are not paged
+type ListCountriesRequest struct {
+	EnabledOnly bool `json:"enabled_only,omitempty"`
+}
+
+type ListCountriesResponse struct {
+	Countries []*Country `json:"countries,omitempty"`
+}
+
+// DisableCountryRequest stops new phone records from using a country, UpdateCountry enables it again
+type DisableCountryRequest struct {
+	CountryId string `json:"country_id,omitempty"`
+}
@@ -0,0 +1,63 @@
+package phonebook
+
+import "context"
+
+// CustomerService manages the customers phone records belong to
+type CustomerService interface {
+	CreateCustomer(context.Context, *Customer) (*Customer, error)
+	GetCustomer(context.Context, *GetCustomerRequest) (*Customer, error)
+	UpdateCustomer(context.Context, *UpdateCustomerRequest) (*Customer, error)
+	ListCustomers(context.Context, *ListCustomersRequest) (*ListCustomersResponse, error)
+	DeleteCustomer(context.Context, *DeleteCustomerRequest) error
+	ListCustomerPhones(context.Context, *ListCustomerPhonesRequest) (*ListPhoneRecordsResponse, error)
+}
+
+// Customer owns phone records, a phone record belongs to the customer whose Id is its CustId
+type Customer struct {
+	Id         string            `json:"id,omitempty"`
+	Name       string            `json:"name,omitempty"`
+	Email      string            `json:"email,omitempty"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+	CreateDate string            `json:"create_date,omitempty"`
+	UpdateDate string            `json:"update_date,omitempty"`
+}
+
+type GetCustomerRequest struct {
+	CustomerId string `json:"customer_id,omitempty"`
+}
+
+type UpdateCustomerRequest struct {
+	Customer   *Customer `json:"customer,omitempty"`
+	UpdateMask []string  `json:"update_mask,omitempty"`
+}
+
+// Update mask paths accepted by UpdateCustomer
+const (
+	UpdateMaskName     = "name"
+	UpdateMaskEmail    = "email"
+	UpdateMaskMetadata = "metadata"
+)
+
+type ListCustomersRequest struct {
+	PageSize  int32  `json:"page_size,omitempty"`
+	PageToken string `json:"page_token,omitempty"`
+}
+
+type ListCustomersResponse struct {
+	Customers       []*Customer `json:"customers,omitempty"`
+	NextPageToken   string      `json:"next_page_token,omitempty"`
+	CollectionCount int32       `json:"collection_count,omitempty"`
+}
+
+// DeleteCustomerRequest deletes a customer, customers with phone records cannot be deleted
+type DeleteCustomerRequest struct {
+	CustomerId string `json:"customer_id,omitempty"`
+}
+
+// ListCustomerPhonesRequest lists the phone records of a customer, paging and ordering work as in ListPhoneRecords
+type ListCustomerPhonesRequest struct {
+	CustomerId string `json:"customer_id,omitempty"`
+	PageSize   int32  `json:"page_size,omitempty"`
+	PageToken  string `json:"page_token,omitempty"`
+	OrderBy    string `json:"order_by,omitempty"`
+}
@@ -0,0 +1,790 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package phonebookpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// PhoneBookServiceClient is the client API for PhoneBookService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PhoneBookServiceClient interface {
+	// Creates a phone record, the number is validated and normalized before it is saved
+	CreatePhoneRecord(ctx context.Context, in *PhoneRecord, opts ...grpc.CallOption) (*PhoneRecord, error)
+	// Retrieves a single phone record
+	GetPhoneRecord(ctx context.Context, in *GetPhoneRecordRequest, opts ...grpc.CallOption) (*PhoneRecord, error)
+	// Updates fields of a phone record named in the update mask, the number is revalidated when number or country change
+	UpdatePhoneRecord(ctx context.Context, in *UpdatePhoneRecordRequest, opts ...grpc.CallOption) (*PhoneRecord, error)
+	// Retrieves a page of phone records
+	ListPhoneRecords(ctx context.Context, in *ListPhoneRecordsRequest, opts ...grpc.CallOption) (*ListPhoneRecordsResponse, error)
+	// Streams every phone record matching the filters
+	ExportPhoneRecords(ctx context.Context, in *ExportPhoneRecordsRequest, opts ...grpc.CallOption) (PhoneBookService_ExportPhoneRecordsClient, error)
+	// Moves a phone record to the trash, it is purged once the retention window passes
+	DeletePhoneRecord(ctx context.Context, in *DeletePhoneRecordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Retrieves a page of phone records in the trash
+	ListDeletedPhoneRecords(ctx context.Context, in *ListPhoneRecordsRequest, opts ...grpc.CallOption) (*ListPhoneRecordsResponse, error)
+	// Moves a phone record out of the trash
+	RestorePhoneRecord(ctx context.Context, in *RestorePhoneRecordRequest, opts ...grpc.CallOption) (*PhoneRecord, error)
+	// Creates many phone records in one transaction, results are reported per item
+	BatchCreatePhoneRecords(ctx context.Context, in *BatchCreatePhoneRecordsRequest, opts ...grpc.CallOption) (*BatchPhoneRecordsResponse, error)
+	// Moves many phone records to the trash in one transaction, results are reported per item
+	BatchDeletePhoneRecords(ctx context.Context, in *BatchDeletePhoneRecordsRequest, opts ...grpc.CallOption) (*BatchPhoneRecordsResponse, error)
+	// Validates a phone number without saving it
+	ValidatePhoneNumber(ctx context.Context, in *ValidatePhoneNumberRequest, opts ...grpc.CallOption) (*ValidatePhoneNumberResponse, error)
+}
+
+type phoneBookServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPhoneBookServiceClient(cc grpc.ClientConnInterface) PhoneBookServiceClient {
+	return &phoneBookServiceClient{cc}
+}
+
+func (c *phoneBookServiceClient) CreatePhoneRecord(ctx context.Context, in *PhoneRecord, opts ...grpc.CallOption) (*PhoneRecord, error) {
+	out := new(PhoneRecord)
+	err := c.cc.Invoke(ctx, "/gidyon.phonebook.v1.PhoneBookService/CreatePhoneRecord", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *phoneBookServiceClient) GetPhoneRecord(ctx context.Context, in *GetPhoneRecordRequest, opts ...grpc.CallOption) (*PhoneRecord, error) {
+	out := new(PhoneRecord)
+	err := c.cc.Invoke(ctx, "/gidyon.phonebook.v1.PhoneBookService/GetPhoneRecord", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *phoneBookServiceClient) UpdatePhoneRecord(ctx context.Context, in *UpdatePhoneRecordRequest, opts ...grpc.CallOption) (*PhoneRecord, error) {
+	out := new(PhoneRecord)
+	err := c.cc.Invoke(ctx, "/gidyon.phonebook.v1.PhoneBookService/UpdatePhoneRecord", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *phoneBookServiceClient) ListPhoneRecords(ctx context.Context, in *ListPhoneRecordsRequest, opts ...grpc.CallOption) (*ListPhoneRecordsResponse, error) {
+	out := new(ListPhoneRecordsResponse)
+	err := c.cc.Invoke(ctx, "/gidyon.phonebook.v1.PhoneBookService/ListPhoneRecords", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *phoneBookServiceClient) ExportPhoneRecords(ctx context.Context, in *ExportPhoneRecordsRequest, opts ...grpc.CallOption) (PhoneBookService_ExportPhoneRecordsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PhoneBookService_ServiceDesc.Streams[0], "/gidyon.phonebook.v1.PhoneBookService/ExportPhoneRecords", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &phoneBookServiceExportPhoneRecordsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PhoneBookService_ExportPhoneRecordsClient interface {
+	Recv() (*PhoneRecord, error)
+	grpc.ClientStream
+}
+
+type phoneBookServiceExportPhoneRecordsClient struct {
+	grpc.ClientStream
+}
+
+func (x *phoneBookServiceExportPhoneRecordsClient) Recv() (*PhoneRecord, error) {
+	m := new(PhoneRecord)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *phoneBookServiceClient) DeletePhoneRecord(ctx context.Context, in *DeletePhoneRecordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/gidyon.phonebook.v1.PhoneBookService/DeletePhoneRecord", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *phoneBookServiceClient) ListDeletedPhoneRecords(ctx context.Context, in *ListPhoneRecordsRequest, opts ...grpc.CallOption) (*ListPhoneRecordsResponse, error) {
+	out := new(ListPhoneRecordsResponse)
+	err := c.cc.Invoke(ctx, "/gidyon.phonebook.v1.PhoneBookService/ListDeletedPhoneRecords", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *phoneBookServiceClient) RestorePhoneRecord(ctx context.Context, in *RestorePhoneRecordRequest, opts ...grpc.CallOption) (*PhoneRecord, error) {
+	out := new(PhoneRecord)
+	err := c.cc.Invoke(ctx, "/gidyon.phonebook.v1.PhoneBookService/RestorePhoneRecord", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *phoneBookServiceClient) BatchCreatePhoneRecords(ctx context.Context, in *BatchCreatePhoneRecordsRequest, opts ...grpc.CallOption) (*BatchPhoneRecordsResponse, error) {
+	out := new(BatchPhoneRecordsResponse)
+	err := c.cc.Invoke(ctx, "/gidyon.phonebook.v1.PhoneBookService/BatchCreatePhoneRecords", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *phoneBookServiceClient) BatchDeletePhoneRecords(ctx context.Context, in *BatchDeletePhoneRecordsRequest, opts ...grpc.CallOption) (*BatchPhoneRecordsResponse, error) {
+	out := new(BatchPhoneRecordsResponse)
+	err := c.cc.Invoke(ctx, "/gidyon.phonebook.v1.PhoneBookService/BatchDeletePhoneRecords", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *phoneBookServiceClient) ValidatePhoneNumber(ctx context.Context, in *ValidatePhoneNumberRequest, opts ...grpc.CallOption) (*ValidatePhoneNumberResponse, error) {
+	out := new(ValidatePhoneNumberResponse)
+	err := c.cc.Invoke(ctx, "/gidyon.phonebook.v1.PhoneBookService/ValidatePhoneNumber", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PhoneBookServiceServer is the server API for PhoneBookService service.
+// All implementations must embed UnimplementedPhoneBookServiceServer
+// for forward compatibility
+type PhoneBookServiceServer interface {
+	// Creates a phone record, the number is validated and normalized before it is saved
+	CreatePhoneRecord(context.Context, *PhoneRecord) (*PhoneRecord, error)
+	// Retrieves a single phone record
+	GetPhoneRecord(context.Context, *GetPhoneRecordRequest) (*PhoneRecord, error)
+	// Updates fields of a phone record named in the update mask, the number is revalidated when number or country change
+	UpdatePhoneRecord(context.Context, *UpdatePhoneRecordRequest) (*PhoneRecord, error)
+	// Retrieves a page of phone records
+	ListPhoneRecords(context.Context, *ListPhoneRecordsRequest) (*ListPhoneRecordsResponse, error)
+	// Streams every phone record matching the filters
+	ExportPhoneRecords(*ExportPhoneRecordsRequest, PhoneBookService_ExportPhoneRecordsServer) error
+	// Moves a phone record to the trash, it is purged once the retention window passes
+	DeletePhoneRecord(context.Context, *DeletePhoneRecordRequest) (*emptypb.Empty, error)
+	// Retrieves a page of phone records in the trash
+	ListDeletedPhoneRecords(context.Context, *ListPhoneRecordsRequest) (*ListPhoneRecordsResponse, error)
+	// Moves a phone record out of the trash
+	RestorePhoneRecord(context.Context, *RestorePhoneRecordRequest) (*PhoneRecord, error)
+	// Creates many phone records in one transaction, results are reported per item
+	BatchCreatePhoneRecords(context.Context, *BatchCreatePhoneRecordsRequest) (*BatchPhoneRecordsResponse, error)
+	// Moves many phone records to the trash in one transaction, results are reported per item
+	BatchDeletePhoneRecords(context.Context, *BatchDeletePhoneRecordsRequest) (*BatchPhoneRecordsResponse, error)
+	// Validates a phone number without saving it
+	ValidatePhoneNumber(context.Context, *ValidatePhoneNumberRequest) (*ValidatePhoneNumberResponse, error)
+	mustEmbedUnimplementedPhoneBookServiceServer()
+}
+
+// UnimplementedPhoneBookServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedPhoneBookServiceServer struct {
+}
+
+func (UnimplementedPhoneBookServiceServer) CreatePhoneRecord(context.Context, *PhoneRecord) (*PhoneRecord, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePhoneRecord not implemented")
+}
+func (UnimplementedPhoneBookServiceServer) GetPhoneRecord(context.Context, *GetPhoneRecordRequest) (*PhoneRecord, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPhoneRecord not implemented")
+}
+func (UnimplementedPhoneBookServiceServer) UpdatePhoneRecord(context.Context, *UpdatePhoneRecordRequest) (*PhoneRecord, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePhoneRecord not implemented")
+}
+func (UnimplementedPhoneBookServiceServer) ListPhoneRecords(context.Context, *ListPhoneRecordsRequest) (*ListPhoneRecordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPhoneRecords not implemented")
+}
+func (UnimplementedPhoneBookServiceServer) ExportPhoneRecords(*ExportPhoneRecordsRequest, PhoneBookService_ExportPhoneRecordsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportPhoneRecords not implemented")
+}
+func (UnimplementedPhoneBookServiceServer) DeletePhoneRecord(context.Context, *DeletePhoneRecordRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePhoneRecord not implemented")
+}
+func (UnimplementedPhoneBookServiceServer) ListDeletedPhoneRecords(context.Context, *ListPhoneRecordsRequest) (*ListPhoneRecordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedPhoneRecords not implemented")
+}
+func (UnimplementedPhoneBookServiceServer) RestorePhoneRecord(context.Context, *RestorePhoneRecordRequest) (*PhoneRecord, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestorePhoneRecord not implemented")
+}
+func (UnimplementedPhoneBookServiceServer) BatchCreatePhoneRecords(context.Context, *BatchCreatePhoneRecordsRequest) (*BatchPhoneRecordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreatePhoneRecords not implemented")
+}
+func (UnimplementedPhoneBookServiceServer) BatchDeletePhoneRecords(context.Context, *BatchDeletePhoneRecordsRequest) (*BatchPhoneRecordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDeletePhoneRecords not implemented")
+}
+func (UnimplementedPhoneBookServiceServer) ValidatePhoneNumber(context.Context, *ValidatePhoneNumberRequest) (*ValidatePhoneNumberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidatePhoneNumber not implemented")
+}
+func (UnimplementedPhoneBookServiceServer) mustEmbedUnimplementedPhoneBookServiceServer() {}
+
+// UnsafePhoneBookServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PhoneBookServiceServer will
+// result in compilation errors.
+type UnsafePhoneBookServiceServer interface {
+	mustEmbedUnimplementedPhoneBookServiceServer()
+}
+
+func RegisterPhoneBookServiceServer(s grpc.ServiceRegistrar, srv PhoneBookServiceServer) {
+	s.RegisterService(&PhoneBookService_ServiceDesc, srv)
+}
+
+func _PhoneBookService_CreatePhoneRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PhoneRecord)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PhoneBookServiceServer).CreatePhoneRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gidyon.phonebook.v1.PhoneBookService/CreatePhoneRecord",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PhoneBookServiceServer).CreatePhoneRecord(ctx, req.(*PhoneRecord))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PhoneBookService_GetPhoneRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPhoneRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PhoneBookServiceServer).GetPhoneRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gidyon.phonebook.v1.PhoneBookService/GetPhoneRecord",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PhoneBookServiceServer).GetPhoneRecord(ctx, req.(*GetPhoneRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PhoneBookService_UpdatePhoneRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePhoneRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PhoneBookServiceServer).UpdatePhoneRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gidyon.phonebook.v1.PhoneBookService/UpdatePhoneRecord",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PhoneBookServiceServer).UpdatePhoneRecord(ctx, req.(*UpdatePhoneRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PhoneBookService_ListPhoneRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPhoneRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PhoneBookServiceServer).ListPhoneRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gidyon.phonebook.v1.PhoneBookService/ListPhoneRecords",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PhoneBookServiceServer).ListPhoneRecords(ctx, req.(*ListPhoneRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PhoneBookService_ExportPhoneRecords_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportPhoneRecordsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PhoneBookServiceServer).ExportPhoneRecords(m, &phoneBookServiceExportPhoneRecordsServer{stream})
+}
+
+type PhoneBookService_ExportPhoneRecordsServer interface {
+	Send(*PhoneRecord) error
+	grpc.ServerStream
+}
+
+type phoneBookServiceExportPhoneRecordsServer struct {
+	grpc.ServerStream
+}
+
+func (x *phoneBookServiceExportPhoneRecordsServer) Send(m *PhoneRecord) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _PhoneBookService_DeletePhoneRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePhoneRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PhoneBookServiceServer).DeletePhoneRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gidyon.phonebook.v1.PhoneBookService/DeletePhoneRecord",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PhoneBookServiceServer).DeletePhoneRecord(ctx, req.(*DeletePhoneRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PhoneBookService_ListDeletedPhoneRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPhoneRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PhoneBookServiceServer).ListDeletedPhoneRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gidyon.phonebook.v1.PhoneBookService/ListDeletedPhoneRecords",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PhoneBookServiceServer).ListDeletedPhoneRecords(ctx, req.(*ListPhoneRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PhoneBookService_RestorePhoneRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestorePhoneRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PhoneBookServiceServer).RestorePhoneRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gidyon.phonebook.v1.PhoneBookService/RestorePhoneRecord",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PhoneBookServiceServer).RestorePhoneRecord(ctx, req.(*RestorePhoneRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PhoneBookService_BatchCreatePhoneRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreatePhoneRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PhoneBookServiceServer).BatchCreatePhoneRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gidyon.phonebook.v1.PhoneBookService/BatchCreatePhoneRecords",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PhoneBookServiceServer).BatchCreatePhoneRecords(ctx, req.(*BatchCreatePhoneRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PhoneBookService_BatchDeletePhoneRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeletePhoneRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PhoneBookServiceServer).BatchDeletePhoneRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gidyon.phonebook.v1.PhoneBookService/BatchDeletePhoneRecords",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PhoneBookServiceServer).BatchDeletePhoneRecords(ctx, req.(*BatchDeletePhoneRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PhoneBookService_ValidatePhoneNumber_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidatePhoneNumberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PhoneBookServiceServer).ValidatePhoneNumber(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gidyon.phonebook.v1.PhoneBookService/ValidatePhoneNumber",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PhoneBookServiceServer).ValidatePhoneNumber(ctx, req.(*ValidatePhoneNumberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PhoneBookService_ServiceDesc is the grpc.ServiceDesc for PhoneBookService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PhoneBookService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gidyon.phonebook.v1.PhoneBookService",
+	HandlerType: (*PhoneBookServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreatePhoneRecord",
+			Handler:    _PhoneBookService_CreatePhoneRecord_Handler,
+		},
+		{
+			MethodName: "GetPhoneRecord",
+			Handler:    _PhoneBookService_GetPhoneRecord_Handler,
+		},
+		{
+			MethodName: "UpdatePhoneRecord",
+			Handler:    _PhoneBookService_UpdatePhoneRecord_Handler,
+		},
+		{
+			MethodName: "ListPhoneRecords",
+			Handler:    _PhoneBookService_ListPhoneRecords_Handler,
+		},
+		{
+			MethodName: "DeletePhoneRecord",
+			Handler:    _PhoneBookService_DeletePhoneRecord_Handler,
+		},
+		{
+			MethodName: "ListDeletedPhoneRecords",
+			Handler:    _PhoneBookService_ListDeletedPhoneRecords_Handler,
+		},
+		{
+			MethodName: "RestorePhoneRecord",
+			Handler:    _PhoneBookService_RestorePhoneRecord_Handler,
+		},
+		{
+			MethodName: "BatchCreatePhoneRecords",
+			Handler:    _PhoneBookService_BatchCreatePhoneRecords_Handler,
+		},
+		{
+			MethodName: "BatchDeletePhoneRecords",
+			Handler:    _PhoneBookService_BatchDeletePhoneRecords_Handler,
+		},
+		{
+			MethodName: "ValidatePhoneNumber",
+			Handler:    _PhoneBookService_ValidatePhoneNumber_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportPhoneRecords",
+			Handler:       _PhoneBookService_ExportPhoneRecords_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "phonebook/v1/phonebook.proto",
+}
+
+// CustomerServiceClient is the client API for CustomerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CustomerServiceClient interface {
+	// Creates a customer
+	CreateCustomer(ctx context.Context, in *Customer, opts ...grpc.CallOption) (*Customer, error)
+	// Retrieves a single customer
+	GetCustomer(ctx context.Context, in *GetCustomerRequest, opts ...grpc.CallOption) (*Customer, error)
+	// Updates fields of a customer named in the update mask
+	UpdateCustomer(ctx context.Context, in *UpdateCustomerRequest, opts ...grpc.CallOption) (*Customer, error)
+	// Retrieves a page of customers, newest first
+	ListCustomers(ctx context.Context, in *ListCustomersRequest, opts ...grpc.CallOption) (*ListCustomersResponse, error)
+	// Deletes a customer that has no phone records
+	DeleteCustomer(ctx context.Context, in *DeleteCustomerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Retrieves a page of the phone records of a customer
+	ListCustomerPhones(ctx context.Context, in *ListCustomerPhonesRequest, opts ...grpc.CallOption) (*ListPhoneRecordsResponse, error)
+}
+
+type customerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCustomerServiceClient(cc grpc.ClientConnInterface) CustomerServiceClient {
+	return &customerServiceClient{cc}
+}
+
+func (c *customerServiceClient) CreateCustomer(ctx context.Context, in *Customer, opts ...grpc.CallOption) (*Customer, error) {
+	out := new(Customer)
+	err := c.cc.Invoke(ctx, "/gidyon.phonebook.v1.CustomerService/CreateCustomer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerServiceClient) GetCustomer(ctx context.Context, in *GetCustomerRequest, opts ...grpc.CallOption) (*Customer, error) {
+	out := new(Customer)
+	err := c.cc.Invoke(ctx, "/gidyon.phonebook.v1.CustomerService/GetCustomer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerServiceClient) UpdateCustomer(ctx context.Context, in *UpdateCustomerRequest, opts ...grpc.CallOption) (*Customer, error) {
+	out := new(Customer)
+	err := c.cc.Invoke(ctx, "/gidyon.phonebook.v1.CustomerService/UpdateCustomer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerServiceClient) ListCustomers(ctx context.Context, in *ListCustomersRequest, opts ...grpc.CallOption) (*ListCustomersResponse, error) {
+	out := new(ListCustomersResponse)
+	err := c.cc.Invoke(ctx, "/gidyon.phonebook.v1.CustomerService/ListCustomers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerServiceClient) DeleteCustomer(ctx context.Context, in *DeleteCustomerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/gidyon.phonebook.v1.CustomerService/DeleteCustomer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerServiceClient) ListCustomerPhones(ctx context.Context, in *ListCustomerPhonesRequest, opts ...grpc.CallOption) (*ListPhoneRecordsResponse, error) {
+	out := new(ListPhoneRecordsResponse)
+	err := c.cc.Invoke(ctx, "/gidyon.phonebook.v1.CustomerService/ListCustomerPhones", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CustomerServiceServer is the server API for CustomerService service.
+// All implementations must embed UnimplementedCustomerServiceServer
+// for forward compatibility
+type CustomerServiceServer interface {
+	// Creates a customer
+	CreateCustomer(context.Context, *Customer) (*Customer, error)
+	// Retrieves a single customer
+	GetCustomer(context.Context, *GetCustomerRequest) (*Customer, error)
+	// Updates fields of a customer named in the update mask
+	UpdateCustomer(context.Context, *UpdateCustomerRequest) (*Customer, error)
+	// Retrieves a page of customers, newest first
+	ListCustomers(context.Context, *ListCustomersRequest) (*ListCustomersResponse, error)
+	// Deletes a customer that has no phone records
+	DeleteCustomer(context.Context, *DeleteCustomerRequest) (*emptypb.Empty, error)
+	// Retrieves a page of the phone records of a customer
+	ListCustomerPhones(context.Context, *ListCustomerPhonesRequest) (*ListPhoneRecordsResponse, error)
+	mustEmbedUnimplementedCustomerServiceServer()
+}
+
+// UnimplementedCustomerServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedCustomerServiceServer struct {
+}
+
+func (UnimplementedCustomerServiceServer) CreateCustomer(context.Context, *Customer) (*Customer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCustomer not implemented")
+}
+func (UnimplementedCustomerServiceServer) GetCustomer(context.Context, *GetCustomerRequest) (*Customer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCustomer not implemented")
+}
+func (UnimplementedCustomerServiceServer) UpdateCustomer(context.Context, *UpdateCustomerRequest) (*Customer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCustomer not implemented")
+}
+func (UnimplementedCustomerServiceServer) ListCustomers(context.Context, *ListCustomersRequest) (*ListCustomersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCustomers not implemented")
+}
+func (UnimplementedCustomerServiceServer) DeleteCustomer(context.Context, *DeleteCustomerRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCustomer not implemented")
+}
+func (UnimplementedCustomerServiceServer) ListCustomerPhones(context.Context, *ListCustomerPhonesRequest) (*ListPhoneRecordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCustomerPhones not implemented")
+}
+func (UnimplementedCustomerServiceServer) mustEmbedUnimplementedCustomerServiceServer() {}
+
+// UnsafeCustomerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CustomerServiceServer will
+// result in compilation errors.
+type UnsafeCustomerServiceServer interface {
+	mustEmbedUnimplementedCustomerServiceServer()
+}
+
+func RegisterCustomerServiceServer(s grpc.ServiceRegistrar, srv CustomerServiceServer) {
+	s.RegisterService(&CustomerService_ServiceDesc, srv)
+}
+
+func _CustomerService_CreateCustomer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Customer)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServiceServer).CreateCustomer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gidyon.phonebook.v1.CustomerService/CreateCustomer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServiceServer).CreateCustomer(ctx, req.(*Customer))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerService_GetCustomer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCustomerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServiceServer).GetCustomer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gidyon.phonebook.v1.CustomerService/GetCustomer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServiceServer).GetCustomer(ctx, req.(*GetCustomerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerService_UpdateCustomer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCustomerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServiceServer).UpdateCustomer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gidyon.phonebook.v1.CustomerService/UpdateCustomer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServiceServer).UpdateCustomer(ctx, req.(*UpdateCustomerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerService_ListCustomers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCustomersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServiceServer).ListCustomers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gidyon.phonebook.v1.CustomerService/ListCustomers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServiceServer).ListCustomers(ctx, req.(*ListCustomersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerService_DeleteCustomer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCustomerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServiceServer).DeleteCustomer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gidyon.phonebook.v1.CustomerService/DeleteCustomer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServiceServer).DeleteCustomer(ctx, req.(*DeleteCustomerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerService_ListCustomerPhones_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCustomerPhonesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServiceServer).ListCustomerPhones(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gidyon.phonebook.v1.CustomerService/ListCustomerPhones",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServiceServer).ListCustomerPhones(ctx, req.(*ListCustomerPhonesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CustomerService_ServiceDesc is the grpc.ServiceDesc for CustomerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CustomerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gidyon.phonebook.v1.CustomerService",
+	HandlerType: (*CustomerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateCustomer",
+			Handler:    _CustomerService_CreateCustomer_Handler,
+		},
+		{
+			MethodName: "GetCustomer",
+			Handler:    _CustomerService_GetCustomer_Handler,
+		},
+		{
+			MethodName: "UpdateCustomer",
+			Handler:    _CustomerService_UpdateCustomer_Handler,
+		},
+		{
+			MethodName: "ListCustomers",
+			Handler:    _CustomerService_ListCustomers_Handler,
+		},
+		{
+			MethodName: "DeleteCustomer",
+			Handler:    _CustomerService_DeleteCustomer_Handler,
+		},
+		{
+			MethodName: "ListCustomerPhones",
+			Handler:    _CustomerService_ListCustomerPhones_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "phonebook/v1/phonebook.proto",
+}
@@ -1,33 +1,78 @@
 // Package phonebook has the interface for managing phone records
 package phonebook
 
-import "context"
+import (
+	"context"
+	"errors"
+)
+
+// ErrPageTokenMismatch matches, with errors.Is, the error of listings given a page token created for other filters,
+// page size or order. Listing again without the token starts from the first page.
+var ErrPageTokenMismatch = errors.New("page token was created for different filters or page size, list again without a page token")
 
 type PhoneBookService interface {
 	CreatePhoneRecord(context.Context, *PhoneRecord) (*PhoneRecord, error)
 	GetPhoneRecord(context.Context, *GetPhoneRecordRequest) (*PhoneRecord, error)
+	UpdatePhoneRecord(context.Context, *UpdatePhoneRecordRequest) (*PhoneRecord, error)
 	ListPhoneRecords(context.Context, *ListPhoneRecordsRequest) (*ListPhoneRecordsResponse, error)
+	ExportPhoneRecords(context.Context, *ExportPhoneRecordsRequest, func(*PhoneRecord) error) error
 	DeletePhoneRecord(context.Context, *DeletePhoneRecordRequest) error
+	ListDeletedPhoneRecords(context.Context, *ListPhoneRecordsRequest) (*ListPhoneRecordsResponse, error)
+	RestorePhoneRecord(context.Context, *RestorePhoneRecordRequest) (*PhoneRecord, error)
+	BatchCreatePhoneRecords(context.Context, *BatchCreatePhoneRecordsRequest) (*BatchPhoneRecordsResponse, error)
+	BatchDeletePhoneRecords(context.Context, *BatchDeletePhoneRecordsRequest) (*BatchPhoneRecordsResponse, error)
+	ValidatePhoneNumber(context.Context, *ValidatePhoneNumberRequest) (*ValidatePhoneNumberResponse, error)
 }
 
 type PhoneRecord struct {
-	Id          string `json:"id,omitempty"`
-	CustId      string `json:"cust_id,omitempty"`
-	CountryName string `json:"country_name,omitempty"`
-	CountryCode uint   `json:"country_code,omitempty"`
-	Number      string `json:"number,omitempty"`
-	PhoneValid  bool   `json:"phone_valid,omitempty"`
-	CreateDate  string `json:"create_date,omitempty"`
+	Id          string            `json:"id,omitempty"`
+	CustId      string            `json:"cust_id,omitempty"`
+	CountryName string            `json:"country_name,omitempty"`
+	CountryCode uint              `json:"country_code,omitempty"`
+	Number      string            `json:"number,omitempty"`
+	NumberE164  string            `json:"number_e164,omitempty"`
+	NumberType  string            `json:"number_type,omitempty"`
+	Operator    string            `json:"operator,omitempty"`
+	PhoneValid  bool              `json:"phone_valid,omitempty"`
+	Validation  *ValidationResult `json:"validation,omitempty"`
+	CreateDate  string            `json:"create_date,omitempty"`
+	UpdateDate  string            `json:"update_date,omitempty"`
+	DeleteDate  string            `json:"delete_date,omitempty"`
+	DeletedBy   string            `json:"deleted_by,omitempty"`
+}
+
+type ValidationResult struct {
+	Valid       bool     `json:"valid,omitempty"`
+	CountryName string   `json:"country_name,omitempty"`
+	CountryCode uint     `json:"country_code,omitempty"`
+	NumberType  string   `json:"number_type,omitempty"`
+	Operator    string   `json:"operator,omitempty"`
+	Reasons     []string `json:"reasons,omitempty"`
+	RuleVersion string   `json:"rule_version,omitempty"`
 }
 
 type GetPhoneRecordRequest struct {
 	RecordId string `json:"record_id,omitempty"`
 }
 
+type UpdatePhoneRecordRequest struct {
+	PhoneRecord *PhoneRecord `json:"phone_record,omitempty"`
+	UpdateMask  []string     `json:"update_mask,omitempty"`
+}
+
+// Update mask paths accepted by UpdatePhoneRecord
+const (
+	UpdateMaskNumber  = "number"
+	UpdateMaskCountry = "country"
+	UpdateMaskCustId  = "cust_id"
+)
+
 type ListPhoneRecordsRequest struct {
 	PageSize  int32                `json:"page_size,omitempty"`
 	PageToken string               `json:"page_token,omitempty"`
 	Filters   *PhoneRecordsFilters `json:"filters,omitempty"`
+	// OrderBy is a column and optional direction, e.g. "country_name" or "create_date desc". Defaults to "id desc"
+	OrderBy string `json:"order_by,omitempty"`
 }
 
 type PhoneRecordsFilters struct {
@@ -35,14 +80,85 @@ type PhoneRecordsFilters struct {
 	ValidOnly    bool   `json:"valid_only,omitempty"`
 	NotValidOnly bool   `json:"not_valid_only,omitempty"`
 	PhoneNumber  string `json:"phone_number,omitempty"`
+	NumberType   string `json:"number_type,omitempty"`
+	Operator     string `json:"operator,omitempty"`
+	// PhoneNumberMatch is how PhoneNumber is matched, one of the NumberMatch values. Defaults to NumberMatchExact
+	PhoneNumberMatch string `json:"phone_number_match,omitempty"`
+	// CustIds keeps records of any of the customers
+	CustIds []string `json:"cust_ids,omitempty"`
+	// CreatedAfter and CreatedBefore are RFC3339 times bounding the create date, after is inclusive and before is not
+	CreatedAfter  string `json:"created_after,omitempty"`
+	CreatedBefore string `json:"created_before,omitempty"`
 }
 
+// Ways PhoneNumber filters match numbers. Partial matches only look at the digits of the filter
+const (
+	NumberMatchExact = "exact"
+	// NumberMatchPrefix matches the start of the national number, or of the E.164 number for filters starting with + or 00
+	NumberMatchPrefix   = "prefix"
+	NumberMatchSuffix   = "suffix"
+	NumberMatchContains = "contains"
+)
+
 type ListPhoneRecordsResponse struct {
 	PhoneRecords    []*PhoneRecord `json:"phone_records,omitempty"`
 	NextPageToken   string         `json:"next_page_token,omitempty"`
 	CollectionCount int32          `json:"collection_count,omitempty"`
+	PrevPageToken   string         `json:"prev_page_token,omitempty"`
+	PageNumber      int32          `json:"page_number,omitempty"`
+}
+
+// ExportPhoneRecordsRequest selects every phone record matching Filters, records are sent in listing order
+type ExportPhoneRecordsRequest struct {
+	Filters *PhoneRecordsFilters `json:"filters,omitempty"`
+	OrderBy string               `json:"order_by,omitempty"`
 }
 
 type DeletePhoneRecordRequest struct {
+	RecordId  string `json:"record_id,omitempty"`
+	DeletedBy string `json:"deleted_by,omitempty"`
+}
+
+type RestorePhoneRecordRequest struct {
 	RecordId string `json:"record_id,omitempty"`
 }
+
+// BatchCreatePhoneRecordsRequest creates many phone records, nothing is created when an item fails unless BestEffort is set.
+// ValidateOnly checks the items the same way without creating them, results carry the records that would be created.
+type BatchCreatePhoneRecordsRequest struct {
+	PhoneRecords []*PhoneRecord `json:"phone_records,omitempty"`
+	BestEffort   bool           `json:"best_effort,omitempty"`
+	ValidateOnly bool           `json:"validate_only,omitempty"`
+}
+
+// BatchDeletePhoneRecordsRequest deletes many phone records, nothing is deleted when an item fails unless BestEffort is set
+type BatchDeletePhoneRecordsRequest struct {
+	RecordIds  []string `json:"record_ids,omitempty"`
+	DeletedBy  string   `json:"deleted_by,omitempty"`
+	BestEffort bool     `json:"best_effort,omitempty"`
+}
+
+type BatchPhoneRecordsResponse struct {
+	Results        []*BatchItemResult `json:"results,omitempty"`
+	SucceededCount int32              `json:"succeeded_count,omitempty"`
+	FailedCount    int32              `json:"failed_count,omitempty"`
+}
+
+// BatchItemResult is the outcome for the item at Index of a batch request, Code and Error are empty on success
+type BatchItemResult struct {
+	Index       int32        `json:"index"`
+	RecordId    string       `json:"record_id,omitempty"`
+	PhoneRecord *PhoneRecord `json:"phone_record,omitempty"`
+	Code        string       `json:"code,omitempty"`
+	Error       string       `json:"error,omitempty"`
+}
+
+type ValidatePhoneNumberRequest struct {
+	Number      string `json:"number,omitempty"`
+	CountryName string `json:"country_name,omitempty"`
+}
+
+type ValidatePhoneNumberResponse struct {
+	NumberE164 string            `json:"number_e164,omitempty"`
+	Validation *ValidationResult `json:"validation,omitempty"`
+}
@@ -0,0 +1,146 @@
+package phoneutils
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxE164Digits is the maximum number of digits in an E.164 number, dial code included
+const maxE164Digits = 15
+
+var (
+	ErrEmptyNumber      = errors.New("empty phone number")
+	ErrInvalidNumber    = errors.New("phone number contains invalid characters")
+	ErrUnknownDialCode  = errors.New("phone number has an unknown dial code")
+	ErrMissingDialCode  = errors.New("phone number has no dial code and no country")
+	ErrNumberTooLong    = errors.New("phone number is too long")
+	ErrNoNationalNumber = errors.New("phone number has no digits after the dial code")
+)
+
+// ParsedNumber is a phone number split into its dial code and national significant number
+type ParsedNumber struct {
+	DialCode uint
+	National string
+	// HasDialCode is true when the dial code was written in the number rather than taken from the country
+	HasDialCode bool
+}
+
+// E164 formats the number in E.164 form, e.g. +237697151594
+func (pn *ParsedNumber) E164() string {
+	return fmt.Sprintf("+%d%s", pn.DialCode, pn.National)
+}
+
+// String formats the number as "(cc) nnn", the form matched by country rule patterns
+func (pn *ParsedNumber) String() string {
+	return fmt.Sprintf("(%d) %s", pn.DialCode, pn.National)
+}
+
+// ParseNumber parses number with the rules in DefaultRegistry
+func ParseNumber(number, countryName string) (*ParsedNumber, error) {
+	return DefaultRegistry.Parse(number, countryName)
+}
+
+// NormalizeE164 returns the E.164 form of number, or an empty string when it cannot be parsed
+func NormalizeE164(number, countryName string) string {
+	pn, err := ParseNumber(number, countryName)
+	if err != nil {
+		return ""
+	}
+	return pn.E164()
+}
+
+// Parse accepts numbers in national, international (00cc), "(cc) nnn" and "+cc nnn" forms.
+// Numbers without a dial code are assumed to belong to countryName.
+func (rr *ruleRegistry) Parse(number, countryName string) (*ParsedNumber, error) {
+	number = strings.TrimSpace(number)
+	if number == "" {
+		return nil, ErrEmptyNumber
+	}
+
+	for _, r := range number {
+		if !strings.ContainsRune("0123456789 +-.()/", r) {
+			return nil, ErrInvalidNumber
+		}
+	}
+
+	var (
+		pn  *ParsedNumber
+		err error
+	)
+
+	switch {
+	case strings.HasPrefix(number, "("):
+		end := strings.Index(number, ")")
+		if end < 0 {
+			return nil, ErrInvalidNumber
+		}
+		code, err := strconv.ParseUint(number[1:end], 10, 32)
+		if err != nil {
+			return nil, ErrInvalidNumber
+		}
+		pn = &ParsedNumber{DialCode: uint(code), National: digitsOnly(number[end+1:]), HasDialCode: true}
+	case strings.HasPrefix(number, "+"):
+		pn, err = rr.parseInternational(number[1:])
+	case strings.HasPrefix(number, "00"):
+		pn, err = rr.parseInternational(number[2:])
+	default:
+		rule, ok := rr.Rule(countryName)
+		if !ok {
+			return nil, ErrMissingDialCode
+		}
+		pn = rule.parseNational(digitsOnly(number))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case pn.National == "":
+		return nil, ErrNoNationalNumber
+	case len(fmt.Sprint(pn.DialCode))+len(pn.National) > maxE164Digits:
+		return nil, ErrNumberTooLong
+	}
+
+	return pn, nil
+}
+
+// parseInternational parses a number that follows a "+" or "00" prefix
+func (rr *ruleRegistry) parseInternational(number string) (*ParsedNumber, error) {
+	number = strings.TrimLeft(number, " ")
+
+	// Digits up to the first separator are the dial code when written apart from the rest
+	end := strings.IndexFunc(number, func(r rune) bool { return r < '0' || r > '9' })
+	if end > 0 && end <= 3 {
+		code, _ := strconv.ParseUint(number[:end], 10, 32)
+		return &ParsedNumber{DialCode: uint(code), National: digitsOnly(number[end:]), HasDialCode: true}, nil
+	}
+
+	// Otherwise match against known dial codes, which never prefix one another
+	digits := digitsOnly(number)
+	for n := 1; n <= 3 && n < len(digits); n++ {
+		code, _ := strconv.ParseUint(digits[:n], 10, 32)
+		if rr.hasDialCode(uint(code)) {
+			return &ParsedNumber{DialCode: uint(code), National: digits[n:], HasDialCode: true}, nil
+		}
+	}
+
+	return nil, ErrUnknownDialCode
+}
+
+// parseNational parses digits written without a "+" or "(cc)" prefix
+func (rule *CountryRule) parseNational(digits string) *ParsedNumber {
+	code := fmt.Sprint(rule.DialCode)
+
+	// Dial code written without "+", e.g. 237697151594
+	if !rule.lengthOK(digits) && strings.HasPrefix(digits, code) && rule.lengthOK(digits[len(code):]) {
+		return &ParsedNumber{DialCode: rule.DialCode, National: digits[len(code):], HasDialCode: true}
+	}
+
+	if rule.TrunkPrefix != "" && strings.HasPrefix(digits, rule.TrunkPrefix) && rule.lengthOK(digits[len(rule.TrunkPrefix):]) {
+		digits = digits[len(rule.TrunkPrefix):]
+	}
+
+	return &ParsedNumber{DialCode: rule.DialCode, National: digits}
+}
@@ -0,0 +1,51 @@
+package phoneutils
+
+import "fmt"
+
+// Number types a phone can be classified as
+const (
+	NumberTypeMobile    = "MOBILE"
+	NumberTypeFixedLine = "FIXED_LINE"
+	NumberTypeTollFree  = "TOLL_FREE"
+	NumberTypePremium   = "PREMIUM"
+	NumberTypeUnknown   = "UNKNOWN"
+)
+
+// NumberTypes lists the number types in display order
+var NumberTypes = []string{
+	NumberTypeMobile, NumberTypeFixedLine, NumberTypeTollFree, NumberTypePremium, NumberTypeUnknown,
+}
+
+var numberTypes = map[string]struct{}{
+	NumberTypeMobile:    {},
+	NumberTypeFixedLine: {},
+	NumberTypeTollFree:  {},
+	NumberTypePremium:   {},
+}
+
+func (rule *CountryRule) compileNumberTypes() error {
+	for numberType := range rule.NumberTypes {
+		if _, ok := numberTypes[numberType]; !ok {
+			return fmt.Errorf("country rule %q has unknown number type %q", rule.CountryName, numberType)
+		}
+	}
+
+	table, err := newPrefixTable(rule.NumberTypes)
+	if err != nil {
+		return fmt.Errorf("country rule %q number types: %w", rule.CountryName, err)
+	}
+	rule.typePrefixes = table
+
+	return nil
+}
+
+// NumberType classifies a parsed number using the longest matching prefix of the rule
+func (rule *CountryRule) NumberType(pn *ParsedNumber) string {
+	if pn.DialCode != rule.DialCode {
+		return NumberTypeUnknown
+	}
+	if numberType, ok := rule.typePrefixes.lookup(pn.National); ok {
+		return numberType
+	}
+	return NumberTypeUnknown
+}
@@ -0,0 +1,40 @@
+package phoneutils
+
+import (
+	"fmt"
+	"sort"
+)
+
+func (rule *CountryRule) compileOperators() error {
+	table, err := newPrefixTable(rule.Operators)
+	if err != nil {
+		return fmt.Errorf("country rule %q operators: %w", rule.CountryName, err)
+	}
+	rule.operatorPrefixes = table
+	return nil
+}
+
+// Operator returns the original operator of a parsed number, it is empty when no operator prefix matches
+func (rule *CountryRule) Operator(pn *ParsedNumber) string {
+	if pn.DialCode != rule.DialCode {
+		return ""
+	}
+	operator, _ := rule.operatorPrefixes.lookup(pn.National)
+	return operator
+}
+
+// Operators returns the sorted operator names of all rules in DefaultRegistry
+func Operators() []string {
+	seen := make(map[string]struct{})
+	operators := make([]string, 0, 10)
+	for _, rule := range DefaultRegistry.Rules() {
+		for operator := range rule.Operators {
+			if _, ok := seen[operator]; !ok {
+				seen[operator] = struct{}{}
+				operators = append(operators, operator)
+			}
+		}
+	}
+	sort.Strings(operators)
+	return operators
+}
@@ -1,90 +1,95 @@
 package phoneutils
 
 import (
-	"sync"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
 
-	"github.com/Pallinder/go-randomdata"
+// Directions a page token can move in a listing
+const (
+	PageNext = "n"
+	PagePrev = "p"
 )
 
-type Pagination interface {
-	GetBackPageInfo(sessionId, pageToken string) *PageInfo
-	SetNextPageInfo(sessionId, currentPageToken, nextPageToken string) *PageInfo
-	SessionExist(sessionId string) bool
-	SetNewSession(collectionCount int32, pageToken string) string
-}
+// ErrBadPageToken is returned for page tokens that are malformed or were not signed with the codec key
+var ErrBadPageToken = errors.New("bad page token")
 
-func NewPaginationAPI() Pagination {
-	return &paginationStruct{
-		mu:              sync.RWMutex{},
-		sessionStore:    map[string]map[string]*PageInfo{},
-		collectionCount: 0,
-	}
+// PageToken is everything needed to fetch a page, so no session is kept on the server
+type PageToken struct {
+	// Direction is PageNext for records after Cursor in listing order and PagePrev for records before it
+	Direction string `json:"d"`
+	// Cursor is the id of the record bounding the page, it is not part of the page
+	Cursor uint `json:"c"`
+	// SortValue is the sort column value of the Cursor record when a listing is not ordered by id
+	SortValue       string `json:"v,omitempty"`
+	PageNumber      int32  `json:"n"`
+	CollectionCount int32  `json:"t"`
+	// FilterHash identifies the query the token belongs to so it cannot be replayed against another
+	FilterHash string `json:"f,omitempty"`
 }
 
-type paginationStruct struct {
-	mu                 sync.RWMutex // guards sessionStore
-	sessionStore       map[string]map[string]*PageInfo
-	collectionCount    int32
-	currentPageCounter int32
+// PageTokenCodec signs page tokens so clients cannot change them
+type PageTokenCodec struct {
+	key []byte
 }
 
-func (ps *paginationStruct) GetBackPageInfo(sessionId, pageToken string) *PageInfo {
-	ps.mu.RLock()
-	defer ps.mu.RUnlock()
-	_, ok := ps.sessionStore[sessionId]
-	if ok {
-		if v, ok := ps.sessionStore[sessionId][pageToken]; ok {
-			return v
+// NewPageTokenCodec creates a codec signing with key, a random key is used when key is empty.
+// Replicas must share the key to accept each other's tokens.
+func NewPageTokenCodec(key []byte) (*PageTokenCodec, error) {
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
 		}
 	}
+	return &PageTokenCodec{key: key}, nil
+}
 
-	return &PageInfo{
-		PageToken:       pageToken,
-		PageNumber:      0,
-		CollectionCount: ps.collectionCount,
-	}
+// Encode returns the token as url safe text
+func (ptc *PageTokenCodec) Encode(pt *PageToken) string {
+	payload, _ := json.Marshal(pt)
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString(payload) + "." + enc.EncodeToString(ptc.sign(payload))
 }
 
-func (ps *paginationStruct) SetNextPageInfo(sessionId, currentPageToken, nextPageToken string) *PageInfo {
-	ps.mu.Lock()
-	defer ps.mu.Unlock()
-	_, ok := ps.sessionStore[sessionId]
-	if ok {
-		ps.currentPageCounter++
-		pi := &PageInfo{
-			PageToken:       nextPageToken,
-			PageNumber:      ps.currentPageCounter,
-			CollectionCount: ps.collectionCount,
-		}
-		ps.sessionStore[sessionId][currentPageToken] = pi
-		return pi
+// Decode checks the signature of a token from Encode and returns its contents
+func (ptc *PageTokenCodec) Decode(token string) (*PageToken, error) {
+	i := strings.IndexByte(token, '.')
+	if i == -1 {
+		return nil, ErrBadPageToken
 	}
-	return nil
-}
 
-func (ps *paginationStruct) SessionExist(sessionId string) bool {
-	ps.mu.RLock()
-	defer ps.mu.RUnlock()
-	_, ok := ps.sessionStore[sessionId]
-	return ok
-}
+	enc := base64.RawURLEncoding
+	payload, err := enc.DecodeString(token[:i])
+	if err != nil {
+		return nil, ErrBadPageToken
+	}
+	sig, err := enc.DecodeString(token[i+1:])
+	if err != nil {
+		return nil, ErrBadPageToken
+	}
+	if !hmac.Equal(sig, ptc.sign(payload)) {
+		return nil, ErrBadPageToken
+	}
 
-func (ps *paginationStruct) SetNewSession(collectionCount int32, pageToken string) string {
-	sessionId := randomdata.RandStringRunes(10)
-	ps.mu.Lock()
-	ps.collectionCount = collectionCount
-	ps.currentPageCounter = 1
-	ps.sessionStore[sessionId] = map[string]*PageInfo{
-		pageToken: {
-			PageNumber: 1,
-		},
+	pt := &PageToken{}
+	if err := json.Unmarshal(payload, pt); err != nil {
+		return nil, ErrBadPageToken
+	}
+	if pt.Direction != PageNext && pt.Direction != PagePrev {
+		return nil, ErrBadPageToken
 	}
-	ps.mu.Unlock()
-	return sessionId
+
+	return pt, nil
 }
 
-type PageInfo struct {
-	PageToken       string
-	PageNumber      int32
-	CollectionCount int32
+func (ptc *PageTokenCodec) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, ptc.key)
+	mac.Write(payload)
+	return mac.Sum(nil)
 }
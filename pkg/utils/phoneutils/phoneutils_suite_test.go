@@ -0,0 +1,252 @@
+package phoneutils
+
+import (
+	"strings"
+	"testing"
+
+	phonebook_v1 "github.com/gidyon/jumia-exercise/pkg/api/phonebook/v1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+func TestPhoneUtils(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Phone Utils Suite")
+}
+
+var _ = Describe("Country rules", func() {
+
+	Context("Loading rules", func() {
+		It("should load the default rules", func() {
+			Expect(DefaultRegistry.Rules()).Should(HaveLen(5))
+			Expect(DefaultRegistry.Version()).ShouldNot(BeEmpty())
+		})
+
+		It("should parse json rules", func() {
+			rs, err := ParseRules([]byte(`{"countries":[{"country_name":"Kenya","iso_code":"KE","dial_code":254,"patterns":["\\(254\\)\\ ?7\\d{8}$"]}]}`), "json")
+			Expect(err).ShouldNot(HaveOccurred())
+			rr, err := NewRuleRegistry(rs)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(rr.Version()).ShouldNot(BeEmpty())
+			rule, ok := rr.Rule("kenya")
+			Expect(ok).Should(BeTrue())
+			pn, err := rr.Parse("+254712345678", "")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(rule.Match(pn)).Should(BeTrue())
+		})
+
+		It("should reject rules with bad patterns", func() {
+			_, err := NewRuleRegistry(&RuleSet{Countries: []*CountryRule{
+				{CountryName: "Kenya", DialCode: 254, Patterns: []string{"(254"}},
+			}})
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("should extend rules with the countries they do not have", func() {
+			rs, err := DefaultRules()
+			Expect(err).ShouldNot(HaveOccurred())
+			rs.Version = "v1"
+
+			extended := rs.Extend(
+				&CountryRule{CountryName: "cameroon", DialCode: 237, Patterns: []string{`\d+`}},
+				&CountryRule{CountryName: "Kenya", DialCode: 254, Patterns: []string{`\(254\)\ ?7\d{8}$`}},
+				&CountryRule{CountryName: "KENYA", DialCode: 254, Patterns: []string{`\d+`}},
+			)
+			Expect(extended.Countries).To(HaveLen(len(rs.Countries) + 1))
+			Expect(extended.Version).To(BeEmpty())
+			Expect(rs.Extend().Version).To(Equal("v1"))
+
+			rr, err := NewRuleRegistry(extended)
+			Expect(err).ShouldNot(HaveOccurred())
+			rule, ok := rr.Rule("cameroon")
+			Expect(ok).To(BeTrue())
+			Expect(rule.Patterns).NotTo(ContainElement(`\d+`))
+			Expect(rr.Validate("(254) 712345678", "Kenya").Reasons).To(BeEmpty())
+		})
+
+		It("should reject duplicate countries", func() {
+			_, err := NewRuleRegistry(&RuleSet{Countries: []*CountryRule{
+				{CountryName: "Kenya", DialCode: 254, Patterns: []string{`\d+`}},
+				{CountryName: "kenya", DialCode: 254, Patterns: []string{`\d+`}},
+			}})
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("Normalizing phones", func() {
+		DescribeTable("should normalize to E.164",
+			func(number, country, e164 string) {
+				Expect(NormalizeE164(number, country)).Should(Equal(e164))
+			},
+			Entry("(cc) form", "(237) 697151594", "", "+237697151594"),
+			Entry("+cc form", "+237 697151594", "", "+237697151594"),
+			Entry("+cc form without separator", "+237697151594", "", "+237697151594"),
+			Entry("00cc form", "00237 697151594", "", "+237697151594"),
+			Entry("national form", "697151594", "Cameroon", "+237697151594"),
+			Entry("national form with trunk prefix", "0772 123 456", "Uganda", "+256772123456"),
+			Entry("international form without plus", "237697151594", "Cameroon", "+237697151594"),
+			Entry("national form without country", "697151594", "", ""),
+			Entry("letters", "69715abc", "Cameroon", ""),
+		)
+
+		It("should give the search keys of a number", func() {
+			Expect(NumberSearchKeys("0772 123 456", "Uganda")).To(Equal(&SearchKeys{
+				National: "772123456",
+				Reversed: "654321277652",
+			}))
+			Expect(NumberSearchKeys("69715abc", "Cameroon")).To(Equal(&SearchKeys{National: "69715", Reversed: "51796"}))
+		})
+
+		DescribeTable("should bound digit prefixes",
+			func(prefix, upper string) {
+				Expect(PrefixUpperBound(prefix)).Should(Equal(upper))
+			},
+			Entry("last digit below nine", "6971", "6972"),
+			Entry("trailing nines", "6999", "7"),
+			Entry("only nines", "999", ""),
+		)
+	})
+
+	Context("Classifying phones", func() {
+		DescribeTable("should classify by prefix",
+			func(number, country, numberType string) {
+				Expect(Validate(number, country).NumberType).Should(Equal(numberType))
+			},
+			Entry("mobile", "(237) 697151594", "Cameroon", NumberTypeMobile),
+			Entry("fixed line", "(237) 222123456", "Cameroon", NumberTypeFixedLine),
+			Entry("toll free", "(256) 800123456", "Uganda", NumberTypeTollFree),
+			Entry("premium", "(256) 900123456", "Uganda", NumberTypePremium),
+			Entry("mobile in a prefix range", "(258) 841234567", "Mozambique", NumberTypeMobile),
+			Entry("unknown prefix", "(258) 891234567", "Mozambique", NumberTypeUnknown),
+		)
+
+		It("should reject a prefix in two number types", func() {
+			_, err := NewRuleRegistry(&RuleSet{Countries: []*CountryRule{
+				{CountryName: "Kenya", DialCode: 254, Patterns: []string{`\d+`}, NumberTypes: map[string][]string{
+					NumberTypeMobile:  {"70-79"},
+					NumberTypePremium: {"7"},
+				}},
+			}})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			_, err = NewRuleRegistry(&RuleSet{Countries: []*CountryRule{
+				{CountryName: "Kenya", DialCode: 254, Patterns: []string{`\d+`}, NumberTypes: map[string][]string{
+					NumberTypeMobile:  {"70-79"},
+					NumberTypePremium: {"71"},
+				}},
+			}})
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("Detecting operators", func() {
+		DescribeTable("should detect operator by prefix",
+			func(number, country, operator string) {
+				Expect(Validate(number, country).Operator).Should(Equal(operator))
+			},
+			Entry("MTN Cameroon", "(237) 677151594", "Cameroon", "MTN"),
+			Entry("Orange Cameroon in a prefix range", "(237) 657151594", "Cameroon", "Orange"),
+			Entry("Airtel Uganda", "(256) 704123456", "Uganda", "Airtel"),
+			Entry("MTN Uganda", "(256) 772123456", "Uganda", "MTN"),
+			Entry("unknown prefix", "(256) 800123456", "Uganda", ""),
+		)
+
+		It("should list operators of all countries", func() {
+			Expect(Operators()).Should(ContainElements("MTN", "Orange", "Airtel", "Vodacom"))
+		})
+	})
+
+	Context("Validating phones", func() {
+		It("should accept valid numbers", func() {
+			pr := &phonebook_v1.PhoneRecord{CountryName: "Cameroon", Number: "(237) 697151594"}
+			Expect(ValidatePhone(pr)).Should(BeTrue())
+			Expect(pr.PhoneValid).Should(BeTrue())
+			Expect(pr.CountryCode).Should(BeEquivalentTo(237))
+		})
+
+		It("should accept valid numbers in any form", func() {
+			pr := &phonebook_v1.PhoneRecord{CountryName: "Cameroon", Number: "+237 697151594"}
+			Expect(ValidatePhone(pr)).Should(BeTrue())
+		})
+
+		It("should reject numbers from another country", func() {
+			pr := &phonebook_v1.PhoneRecord{CountryName: "Cameroon", Number: "(256) 697151594"}
+			Expect(ValidatePhone(pr)).Should(BeFalse())
+		})
+
+		It("should reject numbers with wrong length", func() {
+			pr := &phonebook_v1.PhoneRecord{CountryName: "Uganda", Number: "(256) 7041234"}
+			Expect(ValidatePhone(pr)).Should(BeFalse())
+		})
+
+		It("should reject unknown countries", func() {
+			pr := &phonebook_v1.PhoneRecord{CountryName: "Atlantis", Number: "(999) 697151594"}
+			Expect(ValidatePhone(pr)).Should(BeFalse())
+		})
+
+		DescribeTable("should give failure reasons",
+			func(number, country string, reasons ...string) {
+				res := Validate(number, country)
+				Expect(res.Valid).Should(BeFalse())
+				Expect(res.Reasons).Should(Equal(reasons))
+				Expect(res.RuleVersion).Should(Equal(DefaultRegistry.Version()))
+			},
+			Entry("unknown country", "(999) 697151594", "Atlantis", ReasonUnknownCountry),
+			Entry("bad format", "69715abc", "Cameroon", ReasonBadFormat),
+			Entry("country code mismatch", "(256) 697151594", "Cameroon", ReasonCountryCodeMismatch),
+			Entry("wrong length", "(237) 6971515", "Cameroon", ReasonWrongLength),
+			Entry("bad prefix", "(237) 997151594", "Cameroon", ReasonBadPrefix),
+		)
+	})
+})
+
+var _ = Describe("Page tokens", func() {
+	var (
+		codec *PageTokenCodec
+		pt    *PageToken
+	)
+
+	BeforeEach(func() {
+		var err error
+		codec, err = NewPageTokenCodec([]byte("secret"))
+		Expect(err).ShouldNot(HaveOccurred())
+		pt = &PageToken{
+			Direction:       PagePrev,
+			Cursor:          42,
+			PageNumber:      3,
+			CollectionCount: 120,
+			FilterHash:      "abc",
+		}
+	})
+
+	It("should decode what it encodes", func() {
+		got, err := codec.Decode(codec.Encode(pt))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(got).To(Equal(pt))
+	})
+
+	It("should reject edited tokens", func() {
+		token := codec.Encode(pt)
+		pt.Cursor = 43
+		edited := codec.Encode(pt)
+		forged := edited[:strings.IndexByte(edited, '.')] + token[strings.IndexByte(token, '.'):]
+
+		_, err := codec.Decode(forged)
+		Expect(err).To(MatchError(ErrBadPageToken))
+	})
+
+	It("should reject tokens signed with another key", func() {
+		other, err := NewPageTokenCodec(nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = codec.Decode(other.Encode(pt))
+		Expect(err).To(MatchError(ErrBadPageToken))
+	})
+
+	It("should reject malformed tokens", func() {
+		for _, token := range []string{"", "MTA=", "a.b", codec.Encode(pt) + "x"} {
+			_, err := codec.Decode(token)
+			Expect(err).To(MatchError(ErrBadPageToken))
+		}
+	})
+})
@@ -0,0 +1,91 @@
+package phoneutils
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxRangeSize bounds how many prefixes a single range may expand to
+const maxRangeSize = 1000
+
+// prefixTable maps national number prefixes to a value such as a number type or an operator
+type prefixTable []prefixEntry // longest prefix first
+
+type prefixEntry struct {
+	prefix string
+	value  string
+}
+
+// newPrefixTable expands the prefixes of each value, a prefix may not belong to two values
+func newPrefixTable(values map[string][]string) (prefixTable, error) {
+	var (
+		table = make(prefixTable, 0, len(values))
+		seen  = make(map[string]string)
+	)
+
+	for value, prefixes := range values {
+		for _, prefix := range prefixes {
+			expanded, err := expandPrefix(prefix)
+			if err != nil {
+				return nil, fmt.Errorf("bad %s prefix: %w", value, err)
+			}
+			for _, p := range expanded {
+				if other, ok := seen[p]; ok {
+					return nil, fmt.Errorf("prefix %q is set for both %s and %s", p, other, value)
+				}
+				seen[p] = value
+				table = append(table, prefixEntry{prefix: p, value: value})
+			}
+		}
+	}
+
+	sort.Slice(table, func(i, j int) bool {
+		if len(table[i].prefix) != len(table[j].prefix) {
+			return len(table[i].prefix) > len(table[j].prefix)
+		}
+		return table[i].prefix < table[j].prefix
+	})
+
+	return table, nil
+}
+
+// lookup returns the value of the longest prefix of national
+func (table prefixTable) lookup(national string) (string, bool) {
+	for _, entry := range table {
+		if strings.HasPrefix(national, entry.prefix) {
+			return entry.value, true
+		}
+	}
+	return "", false
+}
+
+// expandPrefix expands a prefix range such as "82-87" into its prefixes
+func expandPrefix(prefix string) ([]string, error) {
+	prefix = strings.TrimSpace(prefix)
+
+	parts := strings.Split(prefix, "-")
+	switch {
+	case len(parts) == 1 && prefix != "" && digitsOnly(prefix) == prefix:
+		return []string{prefix}, nil
+	case len(parts) != 2 || len(parts[0]) != len(parts[1]):
+		return nil, fmt.Errorf("bad prefix %q", prefix)
+	}
+
+	from, err1 := strconv.Atoi(parts[0])
+	to, err2 := strconv.Atoi(parts[1])
+	switch {
+	case err1 != nil || err2 != nil || from > to:
+		return nil, fmt.Errorf("bad prefix range %q", prefix)
+	case to-from >= maxRangeSize:
+		return nil, fmt.Errorf("prefix range %q is too large", prefix)
+	}
+
+	prefixes := make([]string, 0, to-from+1)
+	for v := from; v <= to; v++ {
+		prefixes = append(prefixes, fmt.Sprintf("%0*d", len(parts[0]), v))
+	}
+
+	return prefixes, nil
+}
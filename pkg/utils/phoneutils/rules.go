@@ -0,0 +1,285 @@
+package phoneutils
+
+import (
+	"crypto/sha256"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	phonebook_v1 "github.com/gidyon/jumia-exercise/pkg/api/phonebook/v1"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed default_rules.yaml
+var defaultRulesFile []byte
+
+// DefaultRegistry is the registry consulted by ValidatePhone. It starts with the built-in rules
+// and can be reloaded at runtime with Load.
+var DefaultRegistry = mustDefaultRegistry()
+
+// CountryRule describes how phone numbers belonging to a country are validated
+type CountryRule struct {
+	CountryName string   `json:"country_name" yaml:"country_name"`
+	ISOCode     string   `json:"iso_code" yaml:"iso_code"`
+	DialCode    uint     `json:"dial_code" yaml:"dial_code"`
+	TrunkPrefix string   `json:"trunk_prefix,omitempty" yaml:"trunk_prefix,omitempty"`
+	Patterns    []string `json:"patterns" yaml:"patterns"`
+	MinLength   int      `json:"min_length,omitempty" yaml:"min_length,omitempty"`
+	MaxLength   int      `json:"max_length,omitempty" yaml:"max_length,omitempty"`
+	// NumberTypes maps a number type to national number prefixes, a prefix can be a range such as "82-87"
+	NumberTypes map[string][]string `json:"number_types,omitempty" yaml:"number_types,omitempty"`
+	// Operators maps an operator name to the national number prefixes originally allocated to it
+	Operators map[string][]string `json:"operators,omitempty" yaml:"operators,omitempty"`
+
+	regexps          []*regexp.Regexp
+	typePrefixes     prefixTable
+	operatorPrefixes prefixTable
+}
+
+// RuleSet is a versioned collection of country rules, it is the shape of a rules file
+type RuleSet struct {
+	Version   string         `json:"version,omitempty" yaml:"version,omitempty"`
+	Countries []*CountryRule `json:"countries" yaml:"countries"`
+}
+
+// RuleRegistry holds country rules that can be swapped at runtime
+type RuleRegistry interface {
+	Rule(countryName string) (*CountryRule, bool)
+	Rules() []*CountryRule
+	Parse(number, countryName string) (*ParsedNumber, error)
+	Validate(number, countryName string) *phonebook_v1.ValidationResult
+	Version() string
+	Load(*RuleSet) error
+}
+
+// NewRuleRegistry creates a registry with the given rule set
+func NewRuleRegistry(rs *RuleSet) (RuleRegistry, error) {
+	rr := &ruleRegistry{}
+	err := rr.Load(rs)
+	if err != nil {
+		return nil, err
+	}
+	return rr, nil
+}
+
+// DefaultRules returns a copy of the built-in rules, DefaultRegistry may have been loaded with others since
+func DefaultRules() (*RuleSet, error) {
+	rs, err := ParseRules(defaultRulesFile, "yaml")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse default rules: %w", err)
+	}
+	return rs, nil
+}
+
+func mustDefaultRegistry() RuleRegistry {
+	rs, err := DefaultRules()
+	if err != nil {
+		panic(err)
+	}
+	rr, err := NewRuleRegistry(rs)
+	if err != nil {
+		panic(fmt.Errorf("failed to load default rules: %w", err))
+	}
+	return rr
+}
+
+type ruleRegistry struct {
+	mu        sync.RWMutex // guards fields below
+	version   string
+	rules     []*CountryRule
+	byName    map[string]*CountryRule
+	dialCodes map[uint]struct{}
+}
+
+func (rr *ruleRegistry) Rule(countryName string) (*CountryRule, bool) {
+	rr.mu.RLock()
+	defer rr.mu.RUnlock()
+	rule, ok := rr.byName[strings.ToLower(countryName)]
+	return rule, ok
+}
+
+func (rr *ruleRegistry) hasDialCode(dialCode uint) bool {
+	rr.mu.RLock()
+	defer rr.mu.RUnlock()
+	_, ok := rr.dialCodes[dialCode]
+	return ok
+}
+
+func (rr *ruleRegistry) Rules() []*CountryRule {
+	rr.mu.RLock()
+	defer rr.mu.RUnlock()
+	return append([]*CountryRule(nil), rr.rules...)
+}
+
+func (rr *ruleRegistry) Version() string {
+	rr.mu.RLock()
+	defer rr.mu.RUnlock()
+	return rr.version
+}
+
+// Load compiles and validates the rule set then replaces the current rules atomically.
+// Current rules are left untouched if the rule set is invalid.
+func (rr *ruleRegistry) Load(rs *RuleSet) error {
+	if rs == nil {
+		return errors.New("missing rule set")
+	}
+
+	byName := make(map[string]*CountryRule, len(rs.Countries))
+	dialCodes := make(map[uint]struct{}, len(rs.Countries))
+
+	for _, rule := range rs.Countries {
+		if err := rule.compile(); err != nil {
+			return err
+		}
+		key := strings.ToLower(rule.CountryName)
+		if _, ok := byName[key]; ok {
+			return fmt.Errorf("duplicate rule for country %q", rule.CountryName)
+		}
+		byName[key] = rule
+		dialCodes[rule.DialCode] = struct{}{}
+	}
+
+	version := rs.Version
+	if version == "" {
+		version = rs.hash()
+	}
+
+	rr.mu.Lock()
+	rr.version = version
+	rr.rules = rs.Countries
+	rr.byName = byName
+	rr.dialCodes = dialCodes
+	rr.mu.Unlock()
+
+	return nil
+}
+
+// Extend returns the rule set with the rules of countries it does not have added, its own rules are kept.
+// The version of an extended set is derived from its content.
+func (rs *RuleSet) Extend(rules ...*CountryRule) *RuleSet {
+	names := make(map[string]struct{}, len(rs.Countries)+len(rules))
+	for _, rule := range rs.Countries {
+		names[strings.ToLower(rule.CountryName)] = struct{}{}
+	}
+
+	extended := &RuleSet{Version: rs.Version, Countries: append([]*CountryRule(nil), rs.Countries...)}
+	for _, rule := range rules {
+		key := strings.ToLower(rule.CountryName)
+		if _, ok := names[key]; ok {
+			continue
+		}
+		names[key] = struct{}{}
+		extended.Countries = append(extended.Countries, rule)
+		extended.Version = ""
+	}
+	return extended
+}
+
+// hash derives a short version string from the rules content
+func (rs *RuleSet) hash() string {
+	bs, _ := json.Marshal(rs.Countries)
+	return fmt.Sprintf("%x", sha256.Sum256(bs))[:12]
+}
+
+func (rule *CountryRule) compile() error {
+	switch {
+	case rule == nil:
+		return errors.New("nil country rule")
+	case rule.CountryName == "":
+		return errors.New("country rule missing country name")
+	case rule.DialCode == 0:
+		return fmt.Errorf("country rule %q missing dial code", rule.CountryName)
+	case len(rule.Patterns) == 0:
+		return fmt.Errorf("country rule %q missing patterns", rule.CountryName)
+	case rule.MaxLength != 0 && rule.MinLength > rule.MaxLength:
+		return fmt.Errorf("country rule %q has min length greater than max length", rule.CountryName)
+	}
+
+	regexps := make([]*regexp.Regexp, 0, len(rule.Patterns))
+	for _, pattern := range rule.Patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("country rule %q has bad pattern %q: %w", rule.CountryName, pattern, err)
+		}
+		regexps = append(regexps, re)
+	}
+	rule.regexps = regexps
+
+	if err := rule.compileNumberTypes(); err != nil {
+		return err
+	}
+
+	return rule.compileOperators()
+}
+
+// Match checks whether a parsed number has the rule dial code, satisfies the length bounds
+// and matches at least one pattern of the rule
+func (rule *CountryRule) Match(pn *ParsedNumber) bool {
+	if pn.DialCode != rule.DialCode || !rule.lengthOK(pn.National) {
+		return false
+	}
+	return rule.matchPattern(pn)
+}
+
+func (rule *CountryRule) matchPattern(pn *ParsedNumber) bool {
+	formatted := pn.String()
+	for _, re := range rule.regexps {
+		if re.MatchString(formatted) {
+			return true
+		}
+	}
+	return false
+}
+
+func (rule *CountryRule) lengthOK(national string) bool {
+	switch {
+	case rule.MinLength != 0 && len(national) < rule.MinLength:
+		return false
+	case rule.MaxLength != 0 && len(national) > rule.MaxLength:
+		return false
+	}
+	return true
+}
+
+func digitsOnly(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// ParseRules decodes a rule set in json or yaml format
+func ParseRules(data []byte, format string) (*RuleSet, error) {
+	rs := &RuleSet{}
+	var err error
+	switch strings.ToLower(format) {
+	case "json":
+		err = json.Unmarshal(data, rs)
+	case "yaml", "yml":
+		err = yaml.Unmarshal(data, rs)
+	default:
+		return nil, fmt.Errorf("unknown rules format %q", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode rules: %w", err)
+	}
+	return rs, nil
+}
+
+// LoadRulesFile reads a rule set from a .json, .yaml or .yml file
+func LoadRulesFile(path string) (*RuleSet, error) {
+	bs, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules file: %w", err)
+	}
+	return ParseRules(bs, strings.TrimPrefix(filepath.Ext(path), "."))
+}
@@ -0,0 +1,50 @@
+package phoneutils
+
+// SearchKeys are the forms of a phone number stored so it can be searched by parts of its digits
+type SearchKeys struct {
+	// National is the national significant number, or every digit of a number that cannot be parsed
+	National string
+	// Reversed holds the digits of the E.164 number backwards, so a suffix search becomes a prefix search
+	Reversed string
+}
+
+// NumberSearchKeys returns the search keys of number with the rules in DefaultRegistry
+func NumberSearchKeys(number, countryName string) *SearchKeys {
+	pn, err := ParseNumber(number, countryName)
+	if err != nil {
+		digits := digitsOnly(number)
+		return &SearchKeys{National: digits, Reversed: ReverseDigits(digits)}
+	}
+	return &SearchKeys{
+		National: pn.National,
+		Reversed: ReverseDigits(digitsOnly(pn.E164())),
+	}
+}
+
+// SearchDigits normalizes a number search to its digits, so "697-15" searches for "69715"
+func SearchDigits(query string) string {
+	return digitsOnly(query)
+}
+
+// ReverseDigits returns the digits in reverse order
+func ReverseDigits(digits string) string {
+	bs := []byte(digits)
+	for i, j := 0, len(bs)-1; i < j; i, j = i+1, j-1 {
+		bs[i], bs[j] = bs[j], bs[i]
+	}
+	return string(bs)
+}
+
+// PrefixUpperBound returns the smallest digit string that sorts after every string starting with prefix,
+// or an empty string when there is none. It turns a prefix match into a range any index can serve,
+// which LIKE does not do on every database and collation.
+func PrefixUpperBound(prefix string) string {
+	bs := []byte(prefix)
+	for i := len(bs) - 1; i >= 0; i-- {
+		if bs[i] < '9' {
+			bs[i]++
+			return string(bs[:i+1])
+		}
+	}
+	return ""
+}
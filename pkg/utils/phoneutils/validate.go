@@ -1,8 +1,6 @@
 package phoneutils
 
 import (
-	"regexp"
-
 	phonebook_v1 "github.com/gidyon/jumia-exercise/pkg/api/phonebook/v1"
 )
 
@@ -11,39 +9,66 @@ const (
 	NotValidState = "NOT_VALID"
 )
 
-var (
-	cameroonRegexp   = regexp.MustCompile(`\(237\)\ ?[2368]\d{7,8}$`)
-	ethiopiaRegexp   = regexp.MustCompile(`\(251\)\ ?[1-59]\d{8}$`)
-	moroccoRegexp    = regexp.MustCompile(`\(212\)\ ?[5-9]\d{8}$`)
-	mozambiqueRegexp = regexp.MustCompile(`\(258\)\ ?[28]\d{7,8}$`)
-	ugandaRegexp     = regexp.MustCompile(`\(256\)\ ?\d{9}$`)
+// Reasons why a phone number failed validation
+const (
+	ReasonUnknownCountry      = "UNKNOWN_COUNTRY"
+	ReasonBadFormat           = "BAD_FORMAT"
+	ReasonCountryCodeMismatch = "COUNTRY_CODE_MISMATCH"
+	ReasonWrongLength         = "WRONG_LENGTH"
+	ReasonBadPrefix           = "BAD_PREFIX"
 )
 
+// ValidatePhone validates the phone against the rule for its country in DefaultRegistry
 func ValidatePhone(pr *phonebook_v1.PhoneRecord) bool {
-	var valid bool
-	switch pr.CountryName {
-	case "Cameroon":
-		valid = cameroonRegexp.MatchString(pr.Number)
-		pr.CountryCode = 237
-	case "Ethiopia":
-		valid = ethiopiaRegexp.MatchString(pr.Number)
-		pr.CountryCode = 251
-	case "Morocco":
-		valid = moroccoRegexp.MatchString(pr.Number)
-		pr.CountryCode = 212
-	case "Mozambique":
-		valid = mozambiqueRegexp.MatchString(pr.Number)
-		pr.CountryCode = 258
-	case "Uganda":
-		valid = ugandaRegexp.MatchString(pr.Number)
-		pr.CountryCode = 256
-	default:
-		valid = false
+	res := Validate(pr.Number, pr.CountryName)
+	if res.CountryCode != 0 {
+		pr.CountryCode = res.CountryCode
 	}
-	if valid {
-		pr.PhoneValid = true
-	} else {
-		pr.PhoneValid = false
+	pr.PhoneValid = res.Valid
+	pr.NumberType = res.NumberType
+	pr.Operator = res.Operator
+	pr.Validation = res
+	return res.Valid
+}
+
+// Validate validates number against the rule for countryName in DefaultRegistry
+func Validate(number, countryName string) *phonebook_v1.ValidationResult {
+	return DefaultRegistry.Validate(number, countryName)
+}
+
+func (rr *ruleRegistry) Validate(number, countryName string) *phonebook_v1.ValidationResult {
+	res := &phonebook_v1.ValidationResult{
+		RuleVersion: rr.Version(),
+	}
+
+	rule, ok := rr.Rule(countryName)
+	if !ok {
+		res.Reasons = []string{ReasonUnknownCountry}
+		return res
 	}
-	return valid
+
+	res.CountryName = rule.CountryName
+	res.CountryCode = rule.DialCode
+
+	pn, err := rr.Parse(number, countryName)
+	if err != nil {
+		res.Reasons = []string{ReasonBadFormat}
+		return res
+	}
+
+	res.NumberType = rule.NumberType(pn)
+	res.Operator = rule.Operator(pn)
+
+	if pn.DialCode != rule.DialCode {
+		res.Reasons = append(res.Reasons, ReasonCountryCodeMismatch)
+	}
+	if !rule.lengthOK(pn.National) {
+		res.Reasons = append(res.Reasons, ReasonWrongLength)
+	} else if pn.DialCode == rule.DialCode && !rule.matchPattern(pn) {
+		res.Reasons = append(res.Reasons, ReasonBadPrefix)
+	}
+
+	res.Valid = len(res.Reasons) == 0
+
+	return res
 }